@@ -0,0 +1,141 @@
+package http
+
+import (
+	"errors"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"net/http"
+)
+
+// @Summary      List HDD types
+// @Description  HDD types the HDD column of uploaded catalogs can use
+// @Tags         admin
+// @Produce      json
+// @Security     AppKeyAuth
+// @Success      200  {object}  utils.Response{data=[]dto.SpecTypeResp} "List of HDD types"
+// @Router       /admin/hdd-types [get]
+func (s *SCHandler) listHDDTypes(w http.ResponseWriter, r *http.Request) {
+	data, err := s.lookupUseCase.GetHDDTypes(r.Context())
+	renderLookup(w, http.StatusOK, data, err)
+}
+
+// @Summary      Add an HDD type
+// @Description  Adds an HDD type such as NVMe, uploads can use it right away
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     AppKeyAuth
+// @Param        type body dto.SpecTypeCtr true "HDD type"
+// @Success      201  {object}  utils.Response{data=dto.SpecTypeResp} "Created HDD type"
+// @Failure      400  {object}  utils.Response{message=string,error=string} "Invalid HDD type"
+// @Failure      409  {object}  utils.Response{message=string,error=string} "HDD type already exists"
+// @Router       /admin/hdd-types [post]
+func (s *SCHandler) createHDDType(w http.ResponseWriter, r *http.Request) {
+	ctr := &dto.SpecTypeCtr{}
+	if !parseLookup(w, r, ctr) {
+		return
+	}
+	data, err := s.lookupUseCase.CreateHDDType(r.Context(), ctr)
+	renderLookup(w, http.StatusCreated, data, err)
+}
+
+// @Summary      List RAM types
+// @Description  RAM types the RAM column of uploaded catalogs can use
+// @Tags         admin
+// @Produce      json
+// @Security     AppKeyAuth
+// @Success      200  {object}  utils.Response{data=[]dto.SpecTypeResp} "List of RAM types"
+// @Router       /admin/ram-types [get]
+func (s *SCHandler) listRAMTypes(w http.ResponseWriter, r *http.Request) {
+	data, err := s.lookupUseCase.GetRAMTypes(r.Context())
+	renderLookup(w, http.StatusOK, data, err)
+}
+
+// @Summary      Add a RAM type
+// @Description  Adds a RAM type such as DDR6, uploads can use it right away
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     AppKeyAuth
+// @Param        type body dto.SpecTypeCtr true "RAM type"
+// @Success      201  {object}  utils.Response{data=dto.SpecTypeResp} "Created RAM type"
+// @Failure      400  {object}  utils.Response{message=string,error=string} "Invalid RAM type"
+// @Failure      409  {object}  utils.Response{message=string,error=string} "RAM type already exists"
+// @Router       /admin/ram-types [post]
+func (s *SCHandler) createRAMType(w http.ResponseWriter, r *http.Request) {
+	ctr := &dto.SpecTypeCtr{}
+	if !parseLookup(w, r, ctr) {
+		return
+	}
+	data, err := s.lookupUseCase.CreateRAMType(r.Context(), ctr)
+	renderLookup(w, http.StatusCreated, data, err)
+}
+
+// @Summary      List currencies
+// @Description  Currencies the prices of uploaded catalogs can use, by code or symbol
+// @Tags         admin
+// @Produce      json
+// @Security     AppKeyAuth
+// @Success      200  {object}  utils.Response{data=[]dto.CurrencyResp} "List of currencies"
+// @Router       /admin/currencies [get]
+func (s *SCHandler) listCurrencies(w http.ResponseWriter, r *http.Request) {
+	data, err := s.lookupUseCase.GetCurrencies(r.Context())
+	renderLookup(w, http.StatusOK, data, err)
+}
+
+// @Summary      Add a currency
+// @Description  Adds a currency, uploads can use its code or symbol right away
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     AppKeyAuth
+// @Param        currency body dto.CurrencyCtr true "Currency"
+// @Success      201  {object}  utils.Response{data=dto.CurrencyResp} "Created currency"
+// @Failure      400  {object}  utils.Response{message=string,error=string} "Invalid currency"
+// @Failure      409  {object}  utils.Response{message=string,error=string} "Currency code or symbol already exists"
+// @Router       /admin/currencies [post]
+func (s *SCHandler) createCurrency(w http.ResponseWriter, r *http.Request) {
+	ctr := &dto.CurrencyCtr{}
+	if !parseLookup(w, r, ctr) {
+		return
+	}
+	data, err := s.lookupUseCase.CreateCurrency(r.Context(), ctr)
+	renderLookup(w, http.StatusCreated, data, err)
+}
+
+// parseLookup decodes the JSON body of an admin request, invalid bodies are answered with 400
+func parseLookup(w http.ResponseWriter, r *http.Request, ctr interface{}) bool {
+	defer r.Body.Close()
+	if err := utils.ParseJSON(r.Body, ctr); err != nil {
+		_ = (&utils.Response{
+			Status:  http.StatusBadRequest,
+			Message: "invalid request body",
+			Error:   err.Error(),
+		}).Render(w)
+		return false
+	}
+	return true
+}
+
+// renderLookup renders the result of an admin request
+func renderLookup(w http.ResponseWriter, status int, data interface{}, err error) {
+	if err != nil {
+		resp := &utils.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: "unable to update lookups",
+			Error:   err.Error(),
+		}
+		if errors.Is(err, utils.ErrInvalidLookup) {
+			resp.Status, resp.Message = http.StatusBadRequest, "invalid lookup value"
+		} else if errors.Is(err, utils.ErrLookupExists) {
+			resp.Status, resp.Message = http.StatusConflict, "lookup value already exists"
+		}
+		_ = resp.Render(w)
+		return
+	}
+
+	_ = (&utils.Response{
+		Status: status,
+		Data:   data,
+	}).Render(w)
+}
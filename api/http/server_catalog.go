@@ -2,24 +2,38 @@ package http
 
 import (
 	"errors"
+	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	_ "github.com/server-catalog/docs" // This will import the generated docs
+	"github.com/server-catalog/internal/config"
 	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/lookup"
 	"github.com/server-catalog/internal/utils"
 	"github.com/server-catalog/middleware"
+	"github.com/server-catalog/transformer"
 	"github.com/server-catalog/usecase"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"io"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 )
 
 type SCHandler struct {
-	scUseCase usecase.CatalogUseCase
+	scUseCase     usecase.CatalogUseCase
+	jobUseCase    usecase.JobUseCase
+	lookupUseCase usecase.LookupUseCase
+	lookups       *lookup.Registry
 }
 
-func New(router *chi.Mux, cuc usecase.CatalogUseCase) {
+func New(router *chi.Mux, cuc usecase.CatalogUseCase, juc usecase.JobUseCase, luc usecase.LookupUseCase, lookups *lookup.Registry) {
 	handler := &SCHandler{
-		scUseCase: cuc,
+		scUseCase:     cuc,
+		jobUseCase:    juc,
+		lookupUseCase: luc,
+		lookups:       lookups,
 	}
 
 	// Add CORS middleware
@@ -35,12 +49,27 @@ func New(router *chi.Mux, cuc usecase.CatalogUseCase) {
 	router.Route("/api/v1", func(r chi.Router) {
 		r.Use(middleware.AppKeyResolver)
 		r.Post("/upload", handler.uploadCatalog)
+		r.Post("/upload/preview", handler.previewCatalog)
+		r.Post("/upload/diff", handler.diffCatalog)
+		r.Post("/servers/bulk", handler.bulkUpload)
+		r.Get("/jobs/{id}", handler.getJob)
+		r.Get("/uploads", handler.getUploads)
+		r.Get("/versions", handler.getVersions)
+		r.Get("/versions/diff", handler.diffVersions)
+		r.Post("/versions/{id}/activate", handler.activateVersion)
 
 		r.Get("/servers/hdd-types", handler.getHddTypes)
 		r.Get("/servers/locations", handler.getLocations)
 
 		r.Get("/servers/list", handler.getServers)
+		r.Get("/servers/export", handler.exportServers)
 
+		r.Get("/admin/hdd-types", handler.listHDDTypes)
+		r.Post("/admin/hdd-types", handler.createHDDType)
+		r.Get("/admin/ram-types", handler.listRAMTypes)
+		r.Post("/admin/ram-types", handler.createRAMType)
+		r.Get("/admin/currencies", handler.listCurrencies)
+		r.Post("/admin/currencies", handler.createCurrency)
 	})
 	router.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("/swagger/doc.json"),
@@ -49,19 +78,29 @@ func New(router *chi.Mux, cuc usecase.CatalogUseCase) {
 }
 
 // @Summary      Get list of servers
-// @Description  Retrieve a paginated list of servers with optional filtering by storage, RAM, HDD type, and location
+// @Description  Retrieve a paginated list of servers with optional filtering by storage, RAM, HDD type, location, vendor, CPU and price range
 // @Tags         servers
 // @Accept       json
 // @Produce      json
 // @Param        per_page query int false "Number of items per page (default: 10)"
 // @Param        page_no query int false "Page number (default: 1)"
-// @Param        min_storage query string false "Minimum storage (e.g., 1TB)"
-// @Param        max_storage query string false "Maximum storage (e.g., 100TB)"
-// @Param        ram query string false "RAM values (e.g., 2GB,4GB)"
-// @Param        hdd_type query string false "HDD type (e.g., SATA2, SAS, SSD)"
-// @Param        location query string false "Server location (e.g., AmsterdamAMS-01)"
+// @Param        min_storage query string false "Minimum storage of all disk groups together (e.g., 1TB)"
+// @Param        max_storage query string false "Maximum storage of all disk groups together (e.g., 100TB)"
+// @Param        ram query string false "RAM values (e.g., 2GB,4GB,1TB)"
+// @Param        ram_type query string false "RAM type listed by /admin/ram-types (e.g., DDR3, DDR4, DDR5)"
+// @Param        ecc query bool false "Only servers with (true) or without (false) ECC memory"
+// @Param        hdd_type query string false "HDD type of any disk group listed by /admin/hdd-types (e.g., SATA2, SAS, SSD)"
+// @Param        location query string false "Server location as uploaded (AmsterdamAMS-01), datacenter code (AMS-01) or city (Amsterdam)"
+// @Param        vendor query string false "Vendor split from the model (e.g., Dell)"
+// @Param        cpu query string false "Part of the CPU split from the model (e.g., E5-2650)"
+// @Param        currency query string false "ISO 4217 code of the currency listed by /admin/currencies, only servers priced in it are returned (e.g., EUR)"
+// @Param        min_price query number false "Minimum price in the given currency, requires currency (e.g., 50)" minimum(0)
+// @Param        max_price query number false "Maximum price in the given currency, requires currency (e.g., 100)" minimum(0)
+// @Param        version query int false "Catalog version (default: the active version)"
+// @Param        sort query string false "Comma separated sort keys: price, ram, storage, model, location. A leading - sorts descending (e.g., -ram,price). Servers with equal keys are ordered by ID"
 // @Security     AppKeyAuth
 // @Success      200  {object}  utils.Response{data=[]dto.ListServerResp,pagination=utils.Page} "List of servers with pagination"
+// @Failure      400  {object}  utils.Response{message=string,error=string} "Invalid catalog version, RAM type, HDD type, ECC filter, currency, price range or sort key"
 // @Failure      404  {object}  utils.Response{message=string,error=string} "No servers found with the specified filters"
 // @Failure      422  {object}  utils.Response{message=string,error=string} "Unable to fetch servers"
 // @Example      {data} [{"model":"HP DL120G7Intel G850","ram":"4GBDDR3","hdd":"4x1TBSATA2","location":"AmsterdamAMS-01","price":"€39.99"}]
@@ -70,8 +109,116 @@ func New(router *chi.Mux, cuc usecase.CatalogUseCase) {
 func (s *SCHandler) getServers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	ctr, ok := s.listServersCtr(w, r)
+	if !ok {
+		return
+	}
+	if sort := r.URL.Query().Get("sort"); sort != "" {
+		keys, err := utils.ParseSort(sort, utils.ServerSortKeys)
+		if err != nil {
+			_ = (&utils.Response{
+				Status:  http.StatusBadRequest,
+				Message: "invalid sort",
+				Error:   err.Error(),
+			}).Render(w)
+			return
+		}
+		ctr.Sort = keys
+	}
 	page := utils.NewPage(r)
+	ctr.Page = page
+
+	data, err := s.scUseCase.GetListOfServers(ctx, ctr)
+
+	if err != nil {
+		if errors.Is(err, utils.ErrServerNotFound) {
+			_ = (&utils.Response{
+				Status:  http.StatusNotFound,
+				Message: "no server found with these configs",
+				Error:   err.Error(),
+			}).Render(w)
+			return
+		}
+		_ = (&utils.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: "unable to fetch servers",
+			Error:   err.Error(),
+		}).Render(w)
+		return
+	}
 
+	_ = (&utils.Response{
+		Status:     http.StatusOK,
+		Pagination: page,
+		Data:       data,
+	}).Render(w)
+
+	return
+}
+
+// @Summary      Export servers
+// @Description  Download the servers matching the filters of /servers/list as an XLSX workbook.
+// @Description  The workbook has the Model, RAM, HDD, Location and Price header of an upload, so it can be edited and uploaded again unchanged.
+// @Description  Prices are numeric cells formatted with their currency.
+// @Tags         servers
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        format query string false "Output format" Enums(xlsx) default(xlsx)
+// @Param        min_storage query string false "Minimum storage of all disk groups together (e.g., 1TB)"
+// @Param        max_storage query string false "Maximum storage of all disk groups together (e.g., 100TB)"
+// @Param        ram query string false "RAM values (e.g., 2GB,4GB,1TB)"
+// @Param        ram_type query string false "RAM type listed by /admin/ram-types (e.g., DDR3, DDR4, DDR5)"
+// @Param        ecc query bool false "Only servers with (true) or without (false) ECC memory"
+// @Param        hdd_type query string false "HDD type of any disk group listed by /admin/hdd-types (e.g., SATA2, SAS, SSD)"
+// @Param        location query string false "Server location as uploaded (AmsterdamAMS-01), datacenter code (AMS-01) or city (Amsterdam)"
+// @Param        vendor query string false "Vendor split from the model (e.g., Dell)"
+// @Param        cpu query string false "Part of the CPU split from the model (e.g., E5-2650)"
+// @Param        currency query string false "ISO 4217 code of the currency listed by /admin/currencies, only servers priced in it are returned (e.g., EUR)"
+// @Param        min_price query number false "Minimum price in the given currency, requires currency (e.g., 50)" minimum(0)
+// @Param        max_price query number false "Maximum price in the given currency, requires currency (e.g., 100)" minimum(0)
+// @Param        version query int false "Catalog version (default: the active version)"
+// @Security     AppKeyAuth
+// @Success      200  {file}    file "Servers workbook"
+// @Failure      400  {object}  utils.Response{message=string,error=string} "Invalid format, catalog version, RAM type, HDD type, ECC filter, currency or price range"
+// @Failure      422  {object}  utils.Response{message=string,error=string} "Unable to export servers"
+// @Router       /servers/export [get]
+func (s *SCHandler) exportServers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if format := r.URL.Query().Get("format"); format != "" && format != "xlsx" {
+		_ = (&utils.Response{
+			Status:  http.StatusBadRequest,
+			Message: "invalid format",
+			Error:   "format must be xlsx",
+		}).Render(w)
+		return
+	}
+
+	ctr, ok := s.listServersCtr(w, r)
+	if !ok {
+		return
+	}
+
+	f, err := s.scUseCase.ExportServers(ctx, ctr)
+	if err != nil {
+		_ = (&utils.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: "unable to export servers",
+			Error:   err.Error(),
+		}).Render(w)
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", `attachment; filename="servers.xlsx"`)
+	w.WriteHeader(http.StatusOK)
+	_ = f.Write(w)
+}
+
+// listServersCtr builds the list criteria from the filters of the query string. When a
+// filter is invalid, including an unknown RAM or HDD type, the error response is rendered
+// and ok is false.
+func (s *SCHandler) listServersCtr(w http.ResponseWriter, r *http.Request) (*dto.ListServersCtr, bool) {
 	var storageMin, storageMax *int
 	if minStr := r.URL.Query().Get("min_storage"); minStr != "" {
 		if min, err := utils.ParseStorageToGB(minStr); err == nil {
@@ -89,11 +236,46 @@ func (s *SCHandler) getServers(w http.ResponseWriter, r *http.Request) {
 		ramValues = utils.ParseRAMValues(ramStr)
 	}
 
+	var ramTypeID *int
+	if ramType := r.URL.Query().Get("ram_type"); ramType != "" {
+		id, err := s.lookups.RAMTypeID(ramType)
+		if err != nil {
+			_ = (&utils.Response{
+				Status:  http.StatusBadRequest,
+				Message: "invalid ram type filter",
+				Error:   err.Error(),
+			}).Render(w)
+			return nil, false
+		}
+		ramTypeID = &id
+	}
+
+	var ecc *bool
+	if v := r.URL.Query().Get("ecc"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			_ = (&utils.Response{
+				Status:  http.StatusBadRequest,
+				Message: "invalid ecc filter",
+				Error:   err.Error(),
+			}).Render(w)
+			return nil, false
+		}
+		ecc = &b
+	}
+
 	var hddTypeID *int
 	if hdd := r.URL.Query().Get("hdd_type"); hdd != "" {
-		if id, err := utils.GetHDDTypeID(hdd); err == nil {
-			hddTypeID = &id
+		id, err := s.lookups.HDDTypeID(hdd)
+		if err != nil {
+			_ = (&utils.Response{
+				Status:  http.StatusBadRequest,
+				Message: "invalid hdd type filter",
+				Error:   err.Error(),
+			}).Render(w)
+			return nil, false
 		}
+		hddTypeID = &id
 	}
 
 	var location *string
@@ -101,39 +283,73 @@ func (s *SCHandler) getServers(w http.ResponseWriter, r *http.Request) {
 		location = &loc
 	}
 
-	data, err := s.scUseCase.GetListOfServers(ctx, &dto.ListServersCtr{
-		StorageMin: storageMin,
-		StorageMax: storageMax,
-		RAM:        ramValues,
-		HDD:        hddTypeID,
-		Location:   location,
-		Page:       page,
-	})
+	var vendor *string
+	if v := r.URL.Query().Get("vendor"); v != "" {
+		vendor = &v
+	}
 
-	if err != nil {
-		if errors.Is(err, utils.ErrServerNotFound) {
+	var cpu *string
+	if c := r.URL.Query().Get("cpu"); c != "" {
+		cpu = &c
+	}
+
+	var currency *int
+	if code := r.URL.Query().Get("currency"); code != "" {
+		id, err := s.lookups.CurrencyIDByCode(code)
+		if err != nil {
 			_ = (&utils.Response{
-				Status:  http.StatusNotFound,
-				Message: "no server found with these configs",
+				Status:  http.StatusBadRequest,
+				Message: "invalid currency",
 				Error:   err.Error(),
 			}).Render(w)
-			return
+			return nil, false
 		}
+		currency = &id
+	}
+
+	minPrice, maxPrice, err := utils.ParsePriceRange(r.URL.Query().Get("min_price"), r.URL.Query().Get("max_price"))
+	if err == nil && (minPrice != nil || maxPrice != nil) && currency == nil {
+		err = errors.New("currency is required with min_price or max_price")
+	}
+	if err != nil {
 		_ = (&utils.Response{
-			Status:  http.StatusUnprocessableEntity,
-			Message: "unable to fetch servers",
+			Status:  http.StatusBadRequest,
+			Message: "invalid price range",
 			Error:   err.Error(),
 		}).Render(w)
-		return
+		return nil, false
 	}
 
-	_ = (&utils.Response{
-		Status:     http.StatusOK,
-		Pagination: page,
-		Data:       data,
-	}).Render(w)
+	var version *uint
+	if v := r.URL.Query().Get("version"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			_ = (&utils.Response{
+				Status:  http.StatusBadRequest,
+				Message: "invalid catalog version",
+				Error:   err.Error(),
+			}).Render(w)
+			return nil, false
+		}
+		versionID := uint(id)
+		version = &versionID
+	}
 
-	return
+	return &dto.ListServersCtr{
+		StorageMin: storageMin,
+		StorageMax: storageMax,
+		RAM:        ramValues,
+		RAMType:    ramTypeID,
+		ECC:        ecc,
+		HDD:        hddTypeID,
+		Location:   location,
+		Vendor:     vendor,
+		CPU:        cpu,
+		Currency:   currency,
+		MinPrice:   minPrice,
+		MaxPrice:   maxPrice,
+		Version:    version,
+	}, true
 }
 
 // @Summary      Get HDD types
@@ -168,14 +384,14 @@ func (s *SCHandler) getHddTypes(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary      Get server locations
-// @Description  Retrieve a list of all available server locations in the catalog
+// @Description  Retrieve the locations of the active catalog split into city, datacenter code and country
 // @Tags         servers
 // @Accept       json
 // @Produce      json
 // @Security     AppKeyAuth
-// @Success      200  {object}  utils.Response{data=[]string} "List of server locations"
+// @Success      200  {object}  utils.Response{data=[]dto.LocationResp} "List of server locations"
 // @Failure      422  {object}  utils.Response{message=string,error=string} "Unable to fetch locations"
-// @Example      {data} ["AmsterdamAMS-01", "DallasDAL-10", "FrankfurtFRA-10", "Hong KongHKG-10", "San FranciscoSFO-12", "SingaporeSIN-11", "Washington D.C.WDC-01"]
+// @Example      {data} [{"name":"AmsterdamAMS-01","city":"Amsterdam","code":"AMS-01","country":"Netherlands"}]
 // @Router       /servers/locations [get]
 func (s *SCHandler) getLocations(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -199,63 +415,631 @@ func (s *SCHandler) getLocations(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary      Upload server catalog
-// @Description  Upload a server catalog file in XLSX format. The file must contain valid server catalog data.
+// @Description  Upload a server catalog file in XLSX, CSV or TSV format. The file must contain valid server catalog data.
+// @Description  The format is detected from the leading bytes of the file and its content type.
+// @Description  Columns are matched by header name in any order, the header aliases configured in upload.columns are accepted and extra columns are ignored.
+// @Description  Prices carry a currency symbol or ISO 4217 code before or after the amount ($35.99, 39,99 €, EUR 1.299,00), numeric XLSX cells take it from their number format.
+// @Description  Amounts such as 1,299 that read differently depending on the locale are rejected.
+// @Description  With dry_run=true the file is only validated, see /upload/preview.
+// @Description  With async=true the upload is queued and its progress can be polled at /jobs/{id}.
+// @Description  Every upload is recorded in the upload history, see /uploads. A file matching the last successful upload is rejected unless force=true.
+// @Description  The mode decides what happens to the servers already in the catalog: append inserts every row,
+// @Description  replace swaps the whole catalog and upsert updates the price of servers with the same model, RAM, HDD and location.
 // @Tags         servers
 // @Accept       multipart/form-data
 // @Produce      json
-// @Param        file formData file true "Server catalog file (XLSX format)"
+// @Param        file formData file true "Server catalog file (XLSX, CSV or TSV format)"
+// @Param        sheet query string false "Workbook sheets to import: a name, a comma separated list or * for every sheet with a catalog header (default: the first sheet)"
+// @Param        mode query string false "Upload mode" Enums(append, replace, upsert) default(append)
+// @Param        dry_run query bool false "Validate the file without storing it"
+// @Param        async query bool false "Process the upload in the background"
+// @Param        force query bool false "Store the file even when it matches the last successful upload"
 // @Security     AppKeyAuth
-// @Success      201  {object}  utils.Response{message=string} "Catalog uploaded successfully"
+// @Success      201  {object}  utils.Response{message=string,data=dto.UploadCatalogResp} "Catalog uploaded successfully"
+// @Success      202  {object}  utils.Response{data=dto.UploadJobResp} "Upload queued"
 // @Failure      400  {object}  utils.Response{message=string,error=string} "Invalid file format or upload failed"
-// @Failure      422  {object}  utils.Response{message=string,error=string} "Unable to process the file"
+// @Failure      409  {object}  utils.Response{message=string,error=string} "File matches the last successful upload"
+// @Failure      413  {object}  utils.Response{message=string,error=string} "File exceeds the upload size limit"
+// @Failure      422  {object}  utils.Response{message=string,error=utils.Errors} "Invalid catalog rows, keyed by cell reference (Sheet!Cell when sheets were selected)"
+// @Failure      503  {object}  utils.Response{message=string,error=string} "Upload queue is full"
 // @Example      {file} "servers_filters_assignment.xlsx"
 // @Router       /upload [post]
 func (s *SCHandler) uploadCatalog(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	if err := r.ParseMultipartForm(10 << 20); err != nil {
+	if dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run")); dryRun {
+		s.previewCatalog(w, r)
+		return
+	}
+
+	ctr, ok := uploadCatalogCtr(w, r)
+	if !ok {
+		return
+	}
+
+	if async, _ := strconv.ParseBool(r.URL.Query().Get("async")); async {
+		s.submitUpload(w, r, ctr)
+		return
+	}
+	defer ctr.File.Close()
+
+	data, err := s.scUseCase.UploadCatalog(ctx, ctr)
+	if err != nil {
+		renderUploadError(w, err)
+		return
+	}
+
+	_ = (&utils.Response{
+		Status:  http.StatusCreated,
+		Message: "Catalog uploaded",
+		Data:    data,
+	}).Render(w)
+
+	return
+}
+
+// submitUpload queues the upload as a background job
+func (s *SCHandler) submitUpload(w http.ResponseWriter, r *http.Request, ctr *dto.UploadCatalogCtr) {
+	ctx := r.Context()
+
+	data, err := s.jobUseCase.SubmitUpload(ctx, ctr)
+	if err != nil {
+		_ = ctr.File.Close()
+		if errors.Is(err, utils.ErrJobQueueFull) {
+			_ = (&utils.Response{
+				Status:  http.StatusServiceUnavailable,
+				Message: "unable to queue upload",
+				Error:   err.Error(),
+			}).Render(w)
+			return
+		}
+		_ = (&utils.Response{
+			Status:  http.StatusInternalServerError,
+			Message: "unable to queue upload",
+			Error:   err.Error(),
+		}).Render(w)
+		return
+	}
+
+	_ = (&utils.Response{
+		Status:  http.StatusAccepted,
+		Message: "Upload queued",
+		Data:    data,
+	}).Render(w)
+}
+
+// @Summary      Get upload job
+// @Description  Retrieve the state of an asynchronous upload: queued, parsing, inserting, done or failed
+// @Tags         jobs
+// @Produce      json
+// @Param        id path int true "Job ID"
+// @Security     AppKeyAuth
+// @Success      200  {object}  utils.Response{data=dto.UploadJobResp} "Upload job status"
+// @Failure      400  {object}  utils.Response{message=string,error=string} "Invalid job ID"
+// @Failure      404  {object}  utils.Response{message=string,error=string} "Job not found"
+// @Failure      422  {object}  utils.Response{message=string,error=string} "Unable to fetch job"
+// @Router       /jobs/{id} [get]
+func (s *SCHandler) getJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		_ = (&utils.Response{
+			Status:  http.StatusBadRequest,
+			Message: "invalid job id",
+			Error:   err.Error(),
+		}).Render(w)
+		return
+	}
+
+	data, err := s.jobUseCase.GetJob(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, utils.ErrJobNotFound) {
+			_ = (&utils.Response{
+				Status:  http.StatusNotFound,
+				Message: "job not found",
+				Error:   err.Error(),
+			}).Render(w)
+			return
+		}
 		_ = (&utils.Response{
 			Status:  http.StatusUnprocessableEntity,
-			Message: "unable to parse form",
+			Message: "unable to fetch job",
+			Error:   err.Error(),
+		}).Render(w)
+		return
+	}
+
+	_ = (&utils.Response{
+		Status: http.StatusOK,
+		Data:   data,
+	}).Render(w)
+
+	return
+}
+
+// @Summary      List uploads
+// @Description  Retrieve the upload history, newest first: the file or bulk request every upload came from, the App-key that sent it and how it ended
+// @Tags         uploads
+// @Produce      json
+// @Param        per_page query int false "Number of items per page (default: 10)"
+// @Param        page_no query int false "Page number (default: 1)"
+// @Security     AppKeyAuth
+// @Success      200  {object}  utils.Response{data=[]dto.UploadResp,pagination=utils.Page} "Upload history with pagination"
+// @Failure      422  {object}  utils.Response{message=string,error=string} "Unable to fetch uploads"
+// @Router       /uploads [get]
+func (s *SCHandler) getUploads(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page := utils.NewPage(r)
+	data, err := s.scUseCase.GetUploads(ctx, page)
+	if err != nil {
+		_ = (&utils.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: "unable to fetch uploads",
+			Error:   err.Error(),
+		}).Render(w)
+		return
+	}
+
+	_ = (&utils.Response{
+		Status:     http.StatusOK,
+		Pagination: page,
+		Data:       data,
+	}).Render(w)
+
+	return
+}
+
+// @Summary      List catalog versions
+// @Description  Retrieve every catalog version, newest first. Each successful upload creates a new version.
+// @Tags         versions
+// @Produce      json
+// @Security     AppKeyAuth
+// @Success      200  {object}  utils.Response{data=[]dto.CatalogVersionResp} "Catalog versions"
+// @Failure      422  {object}  utils.Response{message=string,error=string} "Unable to fetch versions"
+// @Router       /versions [get]
+func (s *SCHandler) getVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	data, err := s.scUseCase.GetVersions(ctx)
+	if err != nil {
+		_ = (&utils.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: "unable to fetch versions",
 			Error:   err.Error(),
 		}).Render(w)
 		return
 	}
 
-	file, _, err := r.FormFile("file")
+	_ = (&utils.Response{
+		Status: http.StatusOK,
+		Data:   data,
+	}).Render(w)
+
+	return
+}
+
+// @Summary      Activate catalog version
+// @Description  Make a catalog version the active one. Activating an older version rolls back the uploads made after it.
+// @Tags         versions
+// @Produce      json
+// @Param        id path int true "Version ID"
+// @Security     AppKeyAuth
+// @Success      200  {object}  utils.Response{data=dto.CatalogVersionResp} "Activated version"
+// @Failure      400  {object}  utils.Response{message=string,error=string} "Invalid version ID"
+// @Failure      404  {object}  utils.Response{message=string,error=string} "Version not found"
+// @Failure      422  {object}  utils.Response{message=string,error=string} "Unable to activate version"
+// @Router       /versions/{id}/activate [post]
+func (s *SCHandler) activateVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
 		_ = (&utils.Response{
 			Status:  http.StatusBadRequest,
-			Message: "file is required",
+			Message: "invalid version id",
 			Error:   err.Error(),
 		}).Render(w)
 		return
 	}
 
-	if err := s.scUseCase.UploadCatalog(ctx, &dto.UploadCatalogCtr{File: file}); err != nil {
-		if errors.Is(err, utils.ErrUploadFailed) {
+	data, err := s.scUseCase.ActivateVersion(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, utils.ErrVersionNotFound) {
 			_ = (&utils.Response{
-				Status:  http.StatusInternalServerError,
-				Message: "failed to upload file",
+				Status:  http.StatusNotFound,
+				Message: "version not found",
 				Error:   err.Error(),
 			}).Render(w)
 			return
 		}
+		_ = (&utils.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: "unable to activate version",
+			Error:   err.Error(),
+		}).Render(w)
+		return
+	}
+
+	_ = (&utils.Response{
+		Status: http.StatusOK,
+		Data:   data,
+	}).Render(w)
+
+	return
+}
+
+// @Summary      Diff catalog versions
+// @Description  Compare two catalog versions and report added servers, removed servers, price changes and spec changes.
+// @Description  Servers are matched on model, RAM, HDD and location.
+// @Tags         versions
+// @Produce      json
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        from query int true "Version compared against"
+// @Param        to query int false "Compared version (default: the active version)"
+// @Param        format query string false "Output format" Enums(json, xlsx) default(json)
+// @Security     AppKeyAuth
+// @Success      200  {object}  utils.Response{data=dto.CatalogDiffResp} "Catalog diff"
+// @Failure      400  {object}  utils.Response{message=string,error=string} "Invalid version or format"
+// @Failure      404  {object}  utils.Response{message=string,error=string} "Version not found"
+// @Failure      422  {object}  utils.Response{message=string,error=string} "Unable to diff versions"
+// @Router       /versions/diff [get]
+func (s *SCHandler) diffVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !validDiffFormat(w, r) {
+		return
+	}
+
+	from, err := strconv.ParseUint(r.URL.Query().Get("from"), 10, 64)
+	if err != nil {
 		_ = (&utils.Response{
 			Status:  http.StatusBadRequest,
-			Message: "failed to upload file",
+			Message: "invalid from version",
+			Error:   err.Error(),
+		}).Render(w)
+		return
+	}
+	var to uint64
+	if v := r.URL.Query().Get("to"); v != "" {
+		if to, err = strconv.ParseUint(v, 10, 64); err != nil {
+			_ = (&utils.Response{
+				Status:  http.StatusBadRequest,
+				Message: "invalid to version",
+				Error:   err.Error(),
+			}).Render(w)
+			return
+		}
+	}
+
+	data, err := s.scUseCase.DiffVersions(ctx, uint(from), uint(to))
+	if err != nil {
+		if errors.Is(err, utils.ErrVersionNotFound) {
+			_ = (&utils.Response{
+				Status:  http.StatusNotFound,
+				Message: "version not found",
+				Error:   err.Error(),
+			}).Render(w)
+			return
+		}
+		_ = (&utils.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: "unable to diff versions",
 			Error:   err.Error(),
 		}).Render(w)
 		return
 	}
 
-	defer file.Close()
+	renderDiff(w, r, data)
+}
+
+// @Summary      Diff server catalog upload
+// @Description  Parse and validate a server catalog file and compare it against the active catalog without storing it.
+// @Description  Reports added servers, removed servers, price changes and spec changes.
+// @Tags         servers
+// @Accept       multipart/form-data
+// @Produce      json
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        file formData file true "Server catalog file (XLSX, CSV or TSV format)"
+// @Param        sheet query string false "Workbook sheets to import: a name, a comma separated list or * for every sheet with a catalog header (default: the first sheet)"
+// @Param        format query string false "Output format" Enums(json, xlsx) default(json)
+// @Security     AppKeyAuth
+// @Success      200  {object}  utils.Response{data=dto.CatalogDiffResp} "Catalog diff"
+// @Failure      400  {object}  utils.Response{message=string,error=string} "Invalid file or format"
+// @Failure      413  {object}  utils.Response{message=string,error=string} "File exceeds the upload size limit"
+// @Failure      422  {object}  utils.Response{message=string,error=utils.Errors} "Invalid catalog rows, keyed by cell reference (Sheet!Cell when sheets were selected)"
+// @Router       /upload/diff [post]
+func (s *SCHandler) diffCatalog(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !validDiffFormat(w, r) {
+		return
+	}
+
+	ctr, ok := uploadCatalogCtr(w, r)
+	if !ok {
+		return
+	}
+	defer ctr.File.Close()
+
+	data, err := s.scUseCase.DiffCatalog(ctx, ctr)
+	if err != nil {
+		renderUploadError(w, err)
+		return
+	}
+
+	renderDiff(w, r, data)
+}
+
+// validDiffFormat checks the requested diff format, the error response is rendered when it is unknown
+func validDiffFormat(w http.ResponseWriter, r *http.Request) bool {
+	switch r.URL.Query().Get("format") {
+	case "", "json", "xlsx":
+		return true
+	}
+	_ = (&utils.Response{
+		Status:  http.StatusBadRequest,
+		Message: "invalid format",
+		Error:   "format must be json or xlsx",
+	}).Render(w)
+	return false
+}
+
+// renderDiff writes a catalog diff as JSON or, when requested, as an XLSX report
+func renderDiff(w http.ResponseWriter, r *http.Request, diff *dto.CatalogDiffResp) {
+	if r.URL.Query().Get("format") != "xlsx" {
+		_ = (&utils.Response{
+			Status: http.StatusOK,
+			Data:   diff,
+		}).Render(w)
+		return
+	}
+
+	f, err := transformer.DiffReport(diff)
+	if err != nil {
+		_ = (&utils.Response{
+			Status:  http.StatusInternalServerError,
+			Message: "unable to build report",
+			Error:   err.Error(),
+		}).Render(w)
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", `attachment; filename="catalog-diff.xlsx"`)
+	w.WriteHeader(http.StatusOK)
+	_ = f.Write(w)
+}
+
+// @Summary      Preview server catalog upload
+// @Description  Parse and validate a server catalog file without storing it. Returns the normalized rows, the lookup IDs they resolve to and any warnings.
+// @Tags         servers
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file formData file true "Server catalog file (XLSX, CSV or TSV format)"
+// @Param        sheet query string false "Workbook sheets to import: a name, a comma separated list or * for every sheet with a catalog header (default: the first sheet)"
+// @Security     AppKeyAuth
+// @Success      200  {object}  utils.Response{data=dto.CatalogPreviewResp} "Rows as they would be stored"
+// @Failure      400  {object}  utils.Response{message=string,error=string} "Invalid file format"
+// @Failure      413  {object}  utils.Response{message=string,error=string} "File exceeds the upload size limit"
+// @Failure      422  {object}  utils.Response{message=string,error=utils.Errors} "Invalid catalog rows, keyed by cell reference (Sheet!Cell when sheets were selected)"
+// @Router       /upload/preview [post]
+func (s *SCHandler) previewCatalog(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctr, ok := uploadCatalogCtr(w, r)
+	if !ok {
+		return
+	}
+	defer ctr.File.Close()
+
+	data, err := s.scUseCase.PreviewCatalog(ctx, ctr)
+	if err != nil {
+		renderUploadError(w, err)
+		return
+	}
+
+	_ = (&utils.Response{
+		Status: http.StatusOK,
+		Data:   data,
+	}).Render(w)
+
+	return
+}
+
+// @Summary      Bulk upload servers
+// @Description  Upload structured server records as a JSON array or as newline delimited JSON (NDJSON).
+// @Description  Records are validated with the same rules as spreadsheet uploads.
+// @Tags         servers
+// @Accept       json
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Param        servers body []dto.BulkServerRecord true "Server records"
+// @Param        mode query string false "Upload mode" Enums(append, replace, upsert) default(append)
+// @Param        force query bool false "Store the records even when the body matches the last successful upload"
+// @Security     AppKeyAuth
+// @Success      201  {object}  utils.Response{message=string,data=dto.UploadCatalogResp} "Servers uploaded successfully"
+// @Failure      400  {object}  utils.Response{message=string,error=string} "Malformed request body"
+// @Failure      409  {object}  utils.Response{message=string,error=string} "Body matches the last successful upload"
+// @Failure      413  {object}  utils.Response{message=string,error=string} "Body exceeds the upload size limit"
+// @Failure      422  {object}  utils.Response{message=string,error=utils.Errors} "Invalid records, keyed by record and field"
+// @Router       /servers/bulk [post]
+func (s *SCHandler) bulkUpload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	defer r.Body.Close()
+	if maxBytes := config.Upload().MaxBytes; maxBytes > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+	}
+
+	force, _ := strconv.ParseBool(r.URL.Query().Get("force"))
+	data, err := s.scUseCase.BulkUpload(ctx, &dto.BulkUploadCtr{
+		Body:     r.Body,
+		Mode:     r.URL.Query().Get("mode"),
+		Uploader: middleware.KeyIdentity(ctx),
+		Force:    force,
+	})
+	if err != nil {
+		renderUploadError(w, err)
+		return
+	}
 
 	_ = (&utils.Response{
 		Status:  http.StatusCreated,
-		Message: "Catalog uploaded",
-		Error:   err,
+		Message: "Servers uploaded",
+		Data:    data,
 	}).Render(w)
 
 	return
 }
+
+// uploadCatalogCtr builds the upload criteria from the catalog file of a multipart request.
+// When the file can't be read the error response is rendered and ok is false.
+func uploadCatalogCtr(w http.ResponseWriter, r *http.Request) (*dto.UploadCatalogCtr, bool) {
+	file, filename, contentType, err := spoolCatalogFile(w, r)
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		_ = (&utils.Response{
+			Status:  http.StatusRequestEntityTooLarge,
+			Message: "file is too large",
+			Error:   fmt.Sprintf("uploads are limited to %d bytes", maxErr.Limit),
+		}).Render(w)
+		return nil, false
+	}
+	if errors.Is(err, http.ErrMissingFile) {
+		_ = (&utils.Response{
+			Status:  http.StatusBadRequest,
+			Message: "file is required",
+			Error:   err.Error(),
+		}).Render(w)
+		return nil, false
+	}
+	if err != nil {
+		_ = (&utils.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: "unable to parse form",
+			Error:   err.Error(),
+		}).Render(w)
+		return nil, false
+	}
+
+	force, _ := strconv.ParseBool(r.URL.Query().Get("force"))
+	return &dto.UploadCatalogCtr{
+		File:        file,
+		Filename:    filename,
+		ContentType: contentType,
+		Mode:        r.URL.Query().Get("mode"),
+		Uploader:    middleware.KeyIdentity(r.Context()),
+		Force:       force,
+		Sheets:      sheetNames(r),
+	}, true
+}
+
+// sheetNames returns the sheets selected by the sheet query parameters, which
+// may be repeated or hold a comma separated list
+func sheetNames(r *http.Request) []string {
+	var sheets []string
+	for _, param := range r.URL.Query()["sheet"] {
+		for _, name := range strings.Split(param, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				sheets = append(sheets, name)
+			}
+		}
+	}
+	return sheets
+}
+
+// spooledFile is an uploaded file spooled to disk, it is removed once closed
+type spooledFile struct {
+	*os.File
+}
+
+func (f *spooledFile) Close() error {
+	err := f.File.Close()
+	if rmErr := os.Remove(f.Name()); err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// spoolCatalogFile streams the "file" part of a multipart request to disk so that
+// large catalogs are never held in memory. The request body is limited to the
+// MaxBytes of the upload policy. It returns the file, its original name and its
+// content type.
+func spoolCatalogFile(w http.ResponseWriter, r *http.Request) (*spooledFile, string, string, error) {
+	if maxBytes := config.Upload().MaxBytes; maxBytes > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+	}
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, "", "", http.ErrMissingFile
+		}
+		if err != nil {
+			return nil, "", "", err
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		tmp, err := os.CreateTemp(config.Upload().SpoolDir, "catalog-*")
+		if err != nil {
+			return nil, "", "", err
+		}
+		file := &spooledFile{File: tmp}
+		if _, err := io.Copy(file, part); err != nil {
+			_ = file.Close()
+			return nil, "", "", err
+		}
+		return file, part.FileName(), part.Header.Get("Content-Type"), nil
+	}
+}
+
+// renderUploadError renders the response matching an error returned while processing an upload
+func renderUploadError(w http.ResponseWriter, err error) {
+	var verr *utils.ValidationError
+	if errors.As(err, &verr) {
+		_ = (&utils.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: "invalid catalog rows",
+			Error:   verr.Errors(),
+		}).Render(w)
+		return
+	}
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		_ = (&utils.Response{
+			Status:  http.StatusRequestEntityTooLarge,
+			Message: "request body is too large",
+			Error:   fmt.Sprintf("uploads are limited to %d bytes", maxErr.Limit),
+		}).Render(w)
+		return
+	}
+	if errors.Is(err, utils.ErrDuplicateUpload) {
+		_ = (&utils.Response{
+			Status:  http.StatusConflict,
+			Message: "file was already uploaded",
+			Error:   err.Error(),
+		}).Render(w)
+		return
+	}
+	if errors.Is(err, utils.ErrUploadFailed) {
+		_ = (&utils.Response{
+			Status:  http.StatusInternalServerError,
+			Message: "failed to upload file",
+			Error:   err.Error(),
+		}).Render(w)
+		return
+	}
+	_ = (&utils.Response{
+		Status:  http.StatusBadRequest,
+		Message: "failed to upload file",
+		Error:   err.Error(),
+	}).Render(w)
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"context"
+	"github.com/server-catalog/internal/config"
+	"github.com/server-catalog/internal/conn"
+	"github.com/server-catalog/internal/lookup"
+	"github.com/server-catalog/repository"
+	"github.com/server-catalog/usecase"
+)
+
+// catalogUseCase builds the catalog use case against the configured database for
+// the commands working on the catalog without the http server, along with the lookups
+// it renders the catalog with
+func catalogUseCase(ctx context.Context) (usecase.CatalogUseCase, *lookup.Registry, error) {
+	lookups := lookup.New(repository.NewLookup(conn.DefaultDB()))
+	if err := lookups.Refresh(ctx); err != nil {
+		return nil, nil, err
+	}
+	catRepo := repository.NewServerCatalog(conn.DefaultDB(), config.Upload().BatchSize)
+	return usecase.New(catRepo, config.Upload(), lookups), lookups, nil
+}
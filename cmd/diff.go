@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/server-catalog/internal/config"
+	"github.com/server-catalog/internal/conn"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/transformer"
+	"github.com/server-catalog/usecase"
+	"github.com/spf13/cobra"
+	"log"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Diff compares two catalog versions or a catalog file against the active catalog",
+	Example: `  server-catalog diff --from 6 --to 7
+  server-catalog diff --file servers.xlsx --output changes.xlsx`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := config.LoadConfig(); err != nil {
+			log.Fatalln(err)
+		}
+
+		if err := conn.ConnectDB(); err != nil {
+			log.Fatalln(err)
+		}
+	},
+	RunE:          diff,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	initDiffFlags()
+}
+
+// initDiffFlags defines the diff flags, --file replaces both versions so it can't be combined with them
+func initDiffFlags() {
+	diffCmd.Flags().Uint("from", 0, "catalog version compared against")
+	diffCmd.Flags().Uint("to", 0, "compared catalog version (default: the active version)")
+	diffCmd.Flags().String("file", "", "catalog file compared against the active version instead of --to")
+	diffCmd.Flags().StringP("output", "o", "", "write the diff to a .json or .xlsx file instead of stdout")
+	diffCmd.MarkFlagsMutuallyExclusive("file", "from")
+	diffCmd.MarkFlagsMutuallyExclusive("file", "to")
+}
+
+func diff(cmd *cobra.Command, args []string) error {
+	from, _ := cmd.Flags().GetUint("from")
+	to, _ := cmd.Flags().GetUint("to")
+	file, _ := cmd.Flags().GetString("file")
+	output, _ := cmd.Flags().GetString("output")
+
+	if file == "" && from == 0 {
+		return fmt.Errorf("either --from or --file is required")
+	}
+
+	ctx := context.Background()
+	catUseCase, _, err := catalogUseCase(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data *dto.CatalogDiffResp
+	if file != "" {
+		data, err = diffFile(ctx, catUseCase, file)
+	} else {
+		data, err = catUseCase.DiffVersions(ctx, from, to)
+	}
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(filepath.Ext(output), ".xlsx") {
+		report, err := transformer.DiffReport(data)
+		if err != nil {
+			return err
+		}
+		defer report.Close()
+		return report.SaveAs(output)
+	}
+
+	out := os.Stdout
+	if output != "" {
+		if out, err = os.Create(output); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(data)
+}
+
+// diffFile compares a catalog file against the active catalog, invalid rows are printed to stderr
+func diffFile(ctx context.Context, cuc usecase.CatalogUseCase, path string) (*dto.CatalogDiffResp, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := cuc.DiffCatalog(ctx, &dto.UploadCatalogCtr{
+		File:        f,
+		ContentType: mime.TypeByExtension(filepath.Ext(path)),
+	})
+	printRowErrors(os.Stderr, err)
+	return data, err
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffCmd_FileExcludesVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "file and from", args: []string{"--file", "servers.xlsx", "--from", "6"}, wantErr: "[file from]"},
+		{name: "file and to", args: []string{"--file", "servers.xlsx", "--to", "7"}, wantErr: "[file to]"},
+		{name: "versions only", args: []string{"--from", "6", "--to", "7"}},
+		{name: "file only", args: []string{"--file", "servers.xlsx"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffCmd.ResetFlags()
+			initDiffFlags()
+			if err := diffCmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			err := diffCmd.ValidateFlagGroups()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateFlagGroups() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateFlagGroups() error = %v, want it to mention %s", err, tt.wantErr)
+			}
+		})
+	}
+}
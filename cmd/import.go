@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/server-catalog/internal/config"
+	"github.com/server-catalog/internal/conn"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/spf13/cobra"
+	"io"
+	"log"
+	"mime"
+	"os"
+	"os/signal"
+	"os/user"
+	"path/filepath"
+	"syscall"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import loads a catalog file into the configured database",
+	Long: `Import validates a catalog file (XLSX, CSV or TSV) and stores it as a new catalog version,
+exactly like an upload to the http server. Invalid rows are reported on stderr and the
+command exits with a non-zero status when the file isn't imported.`,
+	Example: `  server-catalog import servers.xlsx --mode replace
+  server-catalog import servers.xlsx --sheet EU --sheet US --dry-run`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := config.LoadConfig(); err != nil {
+			log.Fatalln(err)
+		}
+
+		if err := conn.ConnectDB(); err != nil {
+			log.Fatalln(err)
+		}
+	},
+	RunE:          importCatalog,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	importCmd.Flags().String("mode", dto.UploadModeAppend, "upload mode: append, replace or upsert")
+	importCmd.Flags().StringSlice("sheet", nil, "workbook sheets to import, * for every sheet with a catalog header (default: the first sheet)")
+	importCmd.Flags().Bool("dry-run", false, "validate the file without storing it")
+	importCmd.Flags().Bool("force", false, "import the file even when it matches the last successful upload")
+}
+
+func importCatalog(cmd *cobra.Command, args []string) error {
+	mode, _ := cmd.Flags().GetString("mode")
+	sheets, _ := cmd.Flags().GetStringSlice("sheet")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+
+	switch mode {
+	case dto.UploadModeAppend, dto.UploadModeReplace, dto.UploadModeUpsert:
+	default:
+		return fmt.Errorf("unknown upload mode %q, use append, replace or upsert", mode)
+	}
+
+	path := args[0]
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// an interrupted import is rolled back
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	catUseCase, _, err := catalogUseCase(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctr := &dto.UploadCatalogCtr{
+		File:        f,
+		Filename:    filepath.Base(path),
+		ContentType: mime.TypeByExtension(filepath.Ext(path)),
+		Mode:        mode,
+		Sheets:      sheets,
+		Uploader:    cliUploader(),
+		Force:       force,
+	}
+	out := cmd.OutOrStdout()
+
+	if dryRun {
+		data, err := catUseCase.PreviewCatalog(ctx, ctr)
+		if err != nil {
+			printRowErrors(cmd.ErrOrStderr(), err)
+			return err
+		}
+		fmt.Fprintf(out, "%s is valid, %d servers would be imported\n", ctr.Filename, len(data.Rows))
+		printSheets(out, data.Sheets)
+		for _, warning := range data.Warnings {
+			fmt.Fprintln(out, "warning:", warning)
+		}
+		return nil
+	}
+
+	data, err := catUseCase.UploadCatalog(ctx, ctr)
+	if err != nil {
+		printRowErrors(cmd.ErrOrStderr(), err)
+		return err
+	}
+	fmt.Fprintf(out, "imported %s as catalog version %d (upload %d, mode %s)\n", ctr.Filename, data.Version, data.Upload, data.Mode)
+	fmt.Fprintf(out, "  inserted:  %d\n  updated:   %d\n  unchanged: %d\n  deleted:   %d\n",
+		data.Inserted, data.Updated, data.Unchanged, data.Deleted)
+	printSheets(out, data.Sheets)
+	return nil
+}
+
+// cliUploader identifies the system user running a command in the upload history
+func cliUploader() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return "cli:" + u.Username
+	}
+	return "cli"
+}
+
+// printRowErrors prints every invalid cell of a validation error, one per line
+func printRowErrors(w io.Writer, err error) {
+	var verr *utils.ValidationError
+	if errors.As(err, &verr) {
+		for _, row := range verr.Rows {
+			fmt.Fprintln(w, row.String())
+		}
+	}
+}
+
+// printSheets prints the result of every selected sheet
+func printSheets(w io.Writer, sheets []dto.SheetResult) {
+	for _, sheet := range sheets {
+		if sheet.Skipped {
+			fmt.Fprintf(w, "sheet %s: skipped, %s\n", sheet.Name, sheet.Reason)
+			continue
+		}
+		fmt.Fprintf(w, "sheet %s: %d servers\n", sheet.Name, sheet.Servers)
+	}
+}
@@ -1,9 +1,10 @@
 package cmd
 
 import (
+	"fmt"
 	"github.com/server-catalog/cmd/migration"
 	"github.com/spf13/cobra"
-	"log"
+	"os"
 )
 
 var (
@@ -16,11 +17,17 @@ var (
 
 func init() {
 	RootCmd.AddCommand(serveCmd)
+	RootCmd.AddCommand(diffCmd)
+	RootCmd.AddCommand(importCmd)
+	RootCmd.AddCommand(serversCmd)
 	RootCmd.AddCommand(migration.RootCmd)
 }
 
+// Execute runs the root command. The commands silence their own errors, so a
+// failure is printed here exactly once.
 func Execute() {
 	if err := RootCmd.Execute(); err != nil {
-		log.Fatalln(err)
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
 	}
 }
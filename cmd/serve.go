@@ -8,6 +8,7 @@ import (
 	cHttp "github.com/server-catalog/api/http"
 	"github.com/server-catalog/internal/config"
 	"github.com/server-catalog/internal/conn"
+	"github.com/server-catalog/internal/lookup"
 	"github.com/server-catalog/repository"
 	"github.com/server-catalog/usecase"
 	"github.com/spf13/cobra"
@@ -15,6 +16,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 )
 
@@ -44,14 +46,31 @@ func serve(cmd *cobra.Command, args []string) {
 	}
 
 	// initialize repository
-	catRepo := repository.NewServerCatalog(conn.DefaultDB())
+	catRepo := repository.NewServerCatalog(conn.DefaultDB(), config.Upload().BatchSize)
+	jobRepo := repository.NewUploadJob(conn.DefaultDB())
+	lookupRepo := repository.NewLookup(conn.DefaultDB())
+	// load the spec types and currencies
+	lookups := lookup.New(lookupRepo)
+	if err := lookups.Refresh(context.Background()); err != nil {
+		log.Fatalln(err)
+	}
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	if config.App().LookupRefresh > 0 {
+		go lookups.Watch(watchCtx, config.App().LookupRefresh)
+	}
 	// initialize usecase
-	catUseCase := usecase.New(catRepo)
+	catUseCase := usecase.New(catRepo, config.Upload(), lookups)
+	jobUseCase := usecase.NewJobs(jobRepo, catUseCase, config.Upload())
+	if err := jobUseCase.Start(context.Background()); err != nil {
+		log.Fatalln(err)
+	}
+	lookupUseCase := usecase.NewLookup(lookupRepo, lookups)
 
-	cHttp.New(r, catUseCase)
+	cHttp.New(r, catUseCase, jobUseCase, lookupUseCase, lookups)
 
-	stop := make(chan os.Signal)
-	signal.Notify(stop, os.Interrupt)
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
 		log.Println("╔════════════════════════════════════════════╗")
@@ -67,6 +86,20 @@ func serve(cmd *cobra.Command, args []string) {
 
 	<-stop
 
-	ctx, _ := context.WithTimeout(context.Background(), time.Second*5)
-	hServer.Shutdown(ctx)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	if err := hServer.Shutdown(ctx); err != nil {
+		log.Println(err)
+	}
+
+	// let the queued and running upload jobs finish
+	drainTimeout := config.Upload().DrainTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = time.Minute
+	}
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer drainCancel()
+	if err := jobUseCase.Shutdown(drainCtx); err != nil {
+		log.Println("upload jobs interrupted:", err)
+	}
 }
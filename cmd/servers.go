@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/server-catalog/internal/config"
+	"github.com/server-catalog/internal/conn"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/lookup"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/transformer"
+	"github.com/spf13/cobra"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+var serversCmd = &cobra.Command{
+	Use:   "servers",
+	Short: "Servers queries the catalog from a shell without the http server",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := config.LoadConfig(); err != nil {
+			log.Fatalln(err)
+		}
+
+		if err := conn.ConnectDB(); err != nil {
+			log.Fatalln(err)
+		}
+	},
+}
+
+var serversListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List prints the servers matching the filters, like /servers/list",
+	Example: `  server-catalog servers list --location AmsterdamAMS-01 --ram 16GB,32GB --min-storage 1TB
+  server-catalog servers list --hdd-type SSD --sort -storage,price
+  server-catalog servers list --currency EUR --max-price 100 --sort price
+  server-catalog servers list --hdd-type SSD --output csv --file ssd.csv`,
+	Args:          cobra.NoArgs,
+	RunE:          listServers,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+var serversExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export dumps the catalog as an XLSX workbook that can be imported again",
+	Example: `  server-catalog servers export --file servers.xlsx
+  server-catalog servers export --version 6 > servers-v6.xlsx`,
+	Args:          cobra.NoArgs,
+	RunE:          exportServers,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	// the filters of /servers/list, shared by both sub commands
+	filters := serversCmd.PersistentFlags()
+	filters.String("location", "", "server location as uploaded (AmsterdamAMS-01), datacenter code (AMS-01) or city (Amsterdam)")
+	filters.String("ram", "", "RAM values, e.g. 16GB,32GB")
+	filters.String("ram-type", "", "RAM type, e.g. DDR4")
+	filters.String("ecc", "", "only servers with (true) or without (false) ECC memory")
+	filters.String("hdd-type", "", "HDD type of any disk group, e.g. SSD")
+	filters.String("min-storage", "", "minimum storage of all disk groups together, e.g. 1TB")
+	filters.String("max-storage", "", "maximum storage of all disk groups together, e.g. 100TB")
+	filters.String("vendor", "", "vendor split from the model, e.g. Dell")
+	filters.String("cpu", "", "part of the CPU split from the model, e.g. E5-2650")
+	filters.String("currency", "", "only servers priced in the currency with this ISO 4217 code, e.g. EUR")
+	filters.String("min-price", "", "minimum price in --currency, e.g. 50")
+	filters.String("max-price", "", "maximum price in --currency, e.g. 100")
+	filters.Uint("version", 0, "catalog version (default: the active version)")
+	filters.String("file", "", "write to a file instead of stdout")
+
+	serversListCmd.Flags().StringP("output", "o", "table", "output format: table, json, csv or xlsx")
+	serversListCmd.Flags().Int("page", 1, "page of the matching servers")
+	serversListCmd.Flags().Int("per-page", 50, "servers per page")
+	serversListCmd.Flags().String("sort", "", "comma separated sort keys: price, ram, storage, model or location, a leading - sorts descending")
+
+	serversCmd.AddCommand(serversListCmd)
+	serversCmd.AddCommand(serversExportCmd)
+}
+
+func listServers(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString("output")
+	page, _ := cmd.Flags().GetInt("page")
+	perPage, _ := cmd.Flags().GetInt("per-page")
+
+	switch output {
+	case "table", "json", "csv", "xlsx":
+	default:
+		return fmt.Errorf("unknown output format %q, use table, json, csv or xlsx", output)
+	}
+	if page < 1 || perPage < 1 {
+		return fmt.Errorf("--page and --per-page must be positive")
+	}
+
+	ctx := context.Background()
+	catUseCase, lookups, err := catalogUseCase(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctr, err := serverFilters(cmd, lookups)
+	if err != nil {
+		return err
+	}
+	if sort, _ := cmd.Flags().GetString("sort"); sort != "" {
+		if ctr.Sort, err = utils.ParseSort(sort, utils.ServerSortKeys); err != nil {
+			return err
+		}
+	}
+	ctr.Page = &utils.Page{Limit: perPage, Current: page}
+
+	data, err := catUseCase.GetListOfServers(ctx, ctr)
+	if err != nil && !errors.Is(err, utils.ErrServerNotFound) {
+		return err
+	}
+	if data == nil {
+		data = []dto.ListServerResp{}
+	}
+
+	out, closeOut, err := commandOutput(cmd, output == "xlsx")
+	if err != nil {
+		return err
+	}
+	defer closeOut()
+
+	switch output {
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	case "csv":
+		w := csv.NewWriter(out)
+		_ = w.Write([]string{"Model", "RAM", "HDD", "Location", "Price"})
+		for _, server := range data {
+			_ = w.Write([]string{server.Model, server.Ram, server.HDD, server.Location, server.Price})
+		}
+		w.Flush()
+		return w.Error()
+	case "xlsx":
+		report, err := transformer.ServerListReport(data)
+		if err != nil {
+			return err
+		}
+		defer report.Close()
+		return report.Write(out)
+	}
+
+	if len(data) == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "no server found with these configs")
+		return nil
+	}
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODEL\tRAM\tHDD\tLOCATION\tPRICE")
+	for _, server := range data {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", server.Model, server.Ram, server.HDD, server.Location, server.Price)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(data) == perPage {
+		fmt.Fprintf(cmd.ErrOrStderr(), "more servers may match, use --page %d for the next page\n", page+1)
+	}
+	return nil
+}
+
+func exportServers(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	catUseCase, lookups, err := catalogUseCase(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctr, err := serverFilters(cmd, lookups)
+	if err != nil {
+		return err
+	}
+
+	f, err := catUseCase.ExportServers(ctx, ctr)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	out, closeOut, err := commandOutput(cmd, true)
+	if err != nil {
+		return err
+	}
+	defer closeOut()
+	return f.Write(out)
+}
+
+// serverFilters builds the list criteria from the filter flags. Unlike the http
+// handler it also rejects invalid sizes, a typo shouldn't silently widen the list.
+func serverFilters(cmd *cobra.Command, lookups *lookup.Registry) (*dto.ListServersCtr, error) {
+	flags := cmd.Flags()
+	ctr := &dto.ListServersCtr{}
+
+	if v, _ := flags.GetString("min-storage"); v != "" {
+		min, err := utils.ParseStorageToGB(v)
+		if err != nil {
+			return nil, err
+		}
+		ctr.StorageMin = &min
+	}
+	if v, _ := flags.GetString("max-storage"); v != "" {
+		max, err := utils.ParseStorageToGB(v)
+		if err != nil {
+			return nil, err
+		}
+		ctr.StorageMax = &max
+	}
+	if v, _ := flags.GetString("ram"); v != "" {
+		if ctr.RAM = utils.ParseRAMValues(v); len(ctr.RAM) == 0 {
+			return nil, fmt.Errorf("invalid RAM values: %s", v)
+		}
+	}
+	if v, _ := flags.GetString("ram-type"); v != "" {
+		id, err := lookups.RAMTypeID(v)
+		if err != nil {
+			return nil, err
+		}
+		ctr.RAMType = &id
+	}
+	if v, _ := flags.GetString("ecc"); v != "" {
+		ecc, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ecc filter: %s", v)
+		}
+		ctr.ECC = &ecc
+	}
+	if v, _ := flags.GetString("hdd-type"); v != "" {
+		id, err := lookups.HDDTypeID(v)
+		if err != nil {
+			return nil, err
+		}
+		ctr.HDD = &id
+	}
+	if v, _ := flags.GetString("location"); v != "" {
+		ctr.Location = &v
+	}
+	if v, _ := flags.GetString("vendor"); v != "" {
+		ctr.Vendor = &v
+	}
+	if v, _ := flags.GetString("cpu"); v != "" {
+		ctr.CPU = &v
+	}
+	if v, _ := flags.GetString("currency"); v != "" {
+		id, err := lookups.CurrencyIDByCode(v)
+		if err != nil {
+			return nil, err
+		}
+		ctr.Currency = &id
+	}
+	minPrice, _ := flags.GetString("min-price")
+	maxPrice, _ := flags.GetString("max-price")
+	var err error
+	if ctr.MinPrice, ctr.MaxPrice, err = utils.ParsePriceRange(minPrice, maxPrice); err != nil {
+		return nil, err
+	}
+	if (ctr.MinPrice != nil || ctr.MaxPrice != nil) && ctr.Currency == nil {
+		return nil, fmt.Errorf("--currency is required with --min-price or --max-price")
+	}
+	if v, _ := flags.GetUint("version"); v != 0 {
+		ctr.Version = &v
+	}
+	return ctr, nil
+}
+
+// commandOutput returns the file given by --file or stdout. A workbook is never written
+// to a terminal, it would only garble the screen.
+func commandOutput(cmd *cobra.Command, binary bool) (io.Writer, func(), error) {
+	path, _ := cmd.Flags().GetString("file")
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		return f, func() { _ = f.Close() }, nil
+	}
+
+	if binary {
+		if fi, err := os.Stdout.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+			return nil, nil, fmt.Errorf("refusing to write a workbook to a terminal, use --file or redirect stdout")
+		}
+	}
+	return cmd.OutOrStdout(), func() {}, nil
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS upload_job;
\ No newline at end of file
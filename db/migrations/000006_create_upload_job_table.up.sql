@@ -0,0 +1,9 @@
+CREATE TABLE upload_job (
+                            id INT AUTO_INCREMENT PRIMARY KEY,
+                            state VARCHAR(16) NOT NULL,
+                            rows_processed INT NOT NULL DEFAULT 0,
+                            error TEXT,
+                            report TEXT,
+                            created_at DATETIME NOT NULL,
+                            updated_at DATETIME NOT NULL
+);
\ No newline at end of file
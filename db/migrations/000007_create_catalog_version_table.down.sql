@@ -0,0 +1,7 @@
+DELETE FROM server_catalog WHERE version_id <> (SELECT id FROM catalog_version WHERE active = TRUE);
+
+ALTER TABLE server_catalog DROP FOREIGN KEY fk_server_catalog_version;
+
+ALTER TABLE server_catalog DROP COLUMN version_id;
+
+DROP TABLE IF EXISTS catalog_version;
\ No newline at end of file
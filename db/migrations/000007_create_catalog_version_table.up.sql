@@ -0,0 +1,20 @@
+CREATE TABLE catalog_version (
+                                 id INT AUTO_INCREMENT PRIMARY KEY,
+                                 mode VARCHAR(16) NOT NULL,
+                                 server_count INT NOT NULL DEFAULT 0,
+                                 active BOOLEAN NOT NULL DEFAULT FALSE,
+                                 created_at DATETIME NOT NULL,
+                                 activated_at DATETIME NULL
+);
+
+ALTER TABLE server_catalog ADD COLUMN version_id INT NULL;
+
+-- the servers loaded before versioning become the first, active version
+INSERT INTO catalog_version (mode, server_count, active, created_at, activated_at)
+SELECT 'append', COUNT(*), TRUE, NOW(), NOW() FROM server_catalog HAVING COUNT(*) > 0;
+
+UPDATE server_catalog SET version_id = (SELECT id FROM catalog_version WHERE active = TRUE);
+
+ALTER TABLE server_catalog
+    MODIFY version_id INT NOT NULL,
+    ADD CONSTRAINT fk_server_catalog_version FOREIGN KEY (version_id) REFERENCES catalog_version(id);
\ No newline at end of file
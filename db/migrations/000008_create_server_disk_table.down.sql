@@ -0,0 +1,19 @@
+ALTER TABLE server_catalog
+    ADD COLUMN hdd_size INT NULL,
+    ADD COLUMN hdd_count INT NULL,
+    ADD COLUMN hdd_type INT NULL;
+
+-- only the first disk group of mixed configurations survives
+UPDATE server_catalog sc
+    JOIN server_disk sd ON sd.id = (SELECT MIN(id) FROM server_disk WHERE server_id = sc.id)
+SET sc.hdd_size = sd.hdd_size, sc.hdd_count = sd.hdd_count, sc.hdd_type = sd.hdd_type;
+
+DELETE FROM server_catalog WHERE hdd_type IS NULL;
+
+ALTER TABLE server_catalog
+    MODIFY hdd_size INT NOT NULL,
+    MODIFY hdd_count INT NOT NULL,
+    MODIFY hdd_type INT NOT NULL,
+    ADD CONSTRAINT server_catalog_ibfk_2 FOREIGN KEY (hdd_type) REFERENCES hdd_spec(id);
+
+DROP TABLE IF EXISTS server_disk;
\ No newline at end of file
@@ -0,0 +1,21 @@
+CREATE TABLE server_disk (
+                             id INT AUTO_INCREMENT PRIMARY KEY,
+                             server_id INT NOT NULL,
+                             hdd_count INT NOT NULL,
+                             hdd_size INT NOT NULL,
+                             hdd_type INT NOT NULL,
+                             INDEX idx_server_disk_server_id (server_id),
+                             CONSTRAINT fk_server_disk_server FOREIGN KEY (server_id) REFERENCES server_catalog(id) ON DELETE CASCADE,
+                             CONSTRAINT fk_server_disk_hdd_type FOREIGN KEY (hdd_type) REFERENCES hdd_spec(id)
+);
+
+-- every existing server has a single disk group
+INSERT INTO server_disk (server_id, hdd_count, hdd_size, hdd_type)
+SELECT id, hdd_count, hdd_size, hdd_type FROM server_catalog;
+
+-- server_catalog_ibfk_2 is the unnamed hdd_type constraint of 000005
+ALTER TABLE server_catalog
+    DROP FOREIGN KEY server_catalog_ibfk_2,
+    DROP COLUMN hdd_size,
+    DROP COLUMN hdd_count,
+    DROP COLUMN hdd_type;
\ No newline at end of file
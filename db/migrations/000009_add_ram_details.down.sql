@@ -0,0 +1,7 @@
+DELETE FROM server_catalog WHERE ram_type = (SELECT id FROM ram_spec WHERE type = 'DDR5');
+
+ALTER TABLE server_catalog
+    DROP COLUMN ram_speed,
+    DROP COLUMN ram_ecc;
+
+DELETE FROM ram_spec WHERE type = 'DDR5';
\ No newline at end of file
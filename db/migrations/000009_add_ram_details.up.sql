@@ -0,0 +1,5 @@
+INSERT INTO ram_spec (type) VALUES ('DDR5');
+
+ALTER TABLE server_catalog
+    ADD COLUMN ram_ecc BOOLEAN NOT NULL DEFAULT FALSE AFTER ram_type,
+    ADD COLUMN ram_speed INT NOT NULL DEFAULT 0 AFTER ram_ecc;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE server_catalog DROP FOREIGN KEY fk_server_catalog_model;
+
+ALTER TABLE server_catalog DROP COLUMN model_id;
+
+DROP TABLE IF EXISTS server_model;
\ No newline at end of file
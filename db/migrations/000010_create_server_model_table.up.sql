@@ -0,0 +1,20 @@
+CREATE TABLE server_model (
+                              id INT AUTO_INCREMENT PRIMARY KEY,
+                              name VARCHAR(128) NOT NULL UNIQUE,
+                              vendor VARCHAR(64) NOT NULL DEFAULT '',
+                              chassis VARCHAR(64) NOT NULL DEFAULT '',
+                              cpu VARCHAR(128) NOT NULL DEFAULT '',
+                              INDEX idx_server_model_vendor (vendor)
+);
+
+-- the parts of the existing models are filled in when they are uploaded again
+INSERT INTO server_model (name) SELECT DISTINCT model FROM server_catalog;
+
+ALTER TABLE server_catalog ADD COLUMN model_id INT NULL AFTER model;
+
+UPDATE server_catalog sc JOIN server_model sm ON sm.name = sc.model SET sc.model_id = sm.id;
+
+ALTER TABLE server_catalog
+    MODIFY model_id INT NOT NULL,
+    ADD INDEX idx_server_catalog_model_id (model_id),
+    ADD CONSTRAINT fk_server_catalog_model FOREIGN KEY (model_id) REFERENCES server_model(id);
\ No newline at end of file
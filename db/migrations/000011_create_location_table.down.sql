@@ -0,0 +1,5 @@
+ALTER TABLE server_catalog DROP FOREIGN KEY fk_server_catalog_location;
+
+ALTER TABLE server_catalog DROP COLUMN location_id;
+
+DROP TABLE IF EXISTS location;
\ No newline at end of file
@@ -0,0 +1,35 @@
+CREATE TABLE location (
+                              id INT AUTO_INCREMENT PRIMARY KEY,
+                              name VARCHAR(128) NOT NULL UNIQUE,
+                              city VARCHAR(64) NOT NULL DEFAULT '',
+                              code VARCHAR(16) NOT NULL DEFAULT '',
+                              country VARCHAR(64) NOT NULL DEFAULT '',
+                              INDEX idx_location_city (city),
+                              INDEX idx_location_code (code)
+);
+
+-- AmsterdamAMS-01 is split into the city Amsterdam and the datacenter code AMS-01
+INSERT INTO location (name, city, code)
+SELECT DISTINCT location,
+                TRIM(REGEXP_REPLACE(location, '[A-Z]{3}-[0-9]{2,}$', '', 1, 0, 'c')),
+                COALESCE(REGEXP_SUBSTR(location, '[A-Z]{3}-[0-9]{2,}$', 1, 1, 'c'), '')
+FROM server_catalog;
+
+UPDATE location SET country = CASE LEFT(code, 3)
+    WHEN 'AMS' THEN 'Netherlands'
+    WHEN 'DAL' THEN 'United States'
+    WHEN 'FRA' THEN 'Germany'
+    WHEN 'HKG' THEN 'Hong Kong'
+    WHEN 'SFO' THEN 'United States'
+    WHEN 'SIN' THEN 'Singapore'
+    WHEN 'WDC' THEN 'United States'
+    ELSE '' END;
+
+ALTER TABLE server_catalog ADD COLUMN location_id INT NULL AFTER location;
+
+UPDATE server_catalog sc JOIN location l ON l.name = sc.location SET sc.location_id = l.id;
+
+ALTER TABLE server_catalog
+    MODIFY location_id INT NOT NULL,
+    ADD INDEX idx_server_catalog_location_id (location_id),
+    ADD CONSTRAINT fk_server_catalog_location FOREIGN KEY (location_id) REFERENCES location(id);
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE currency
+    DROP INDEX idx_currency_symbol,
+    DROP INDEX idx_currency_code,
+    DROP COLUMN code;
\ No newline at end of file
@@ -0,0 +1,12 @@
+ALTER TABLE currency ADD COLUMN code CHAR(3) NOT NULL DEFAULT '' AFTER id;
+
+UPDATE currency SET code = CASE symbol
+    WHEN '$' THEN 'USD'
+    WHEN '€' THEN 'EUR'
+    WHEN 'S$' THEN 'SGD'
+    ELSE code END;
+
+-- prices are matched on the code or the symbol of their currency
+ALTER TABLE currency
+    ADD UNIQUE INDEX idx_currency_code (code),
+    ADD UNIQUE INDEX idx_currency_symbol (symbol);
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE currency
+    DROP COLUMN thousands_separator,
+    DROP COLUMN decimal_separator,
+    DROP COLUMN decimals,
+    DROP COLUMN symbol_position;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- prices are rendered as $1299.00 unless the currency says otherwise
+ALTER TABLE currency
+    ADD COLUMN symbol_position VARCHAR(8) NOT NULL DEFAULT 'before' AFTER symbol,
+    ADD COLUMN decimals TINYINT NOT NULL DEFAULT 2 AFTER symbol_position,
+    ADD COLUMN decimal_separator VARCHAR(4) NOT NULL DEFAULT '.' AFTER decimals,
+    ADD COLUMN thousands_separator VARCHAR(4) NOT NULL DEFAULT '' AFTER decimal_separator;
\ No newline at end of file
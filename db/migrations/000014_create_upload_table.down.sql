@@ -0,0 +1 @@
+DROP TABLE IF EXISTS upload;
\ No newline at end of file
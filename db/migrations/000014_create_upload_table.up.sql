@@ -0,0 +1,23 @@
+CREATE TABLE upload (
+                        id INT AUTO_INCREMENT PRIMARY KEY,
+                        source VARCHAR(16) NOT NULL,
+                        filename VARCHAR(255) NOT NULL DEFAULT '',
+                        size BIGINT NOT NULL DEFAULT 0,
+                        checksum CHAR(64) NOT NULL,
+                        uploader VARCHAR(64) NOT NULL DEFAULT '',
+                        mode VARCHAR(16) NOT NULL,
+                        forced BOOLEAN NOT NULL DEFAULT FALSE,
+                        outcome VARCHAR(16) NOT NULL,
+                        error TEXT,
+                        row_count INT NOT NULL DEFAULT 0,
+                        inserted INT NOT NULL DEFAULT 0,
+                        updated INT NOT NULL DEFAULT 0,
+                        unchanged INT NOT NULL DEFAULT 0,
+                        deleted INT NOT NULL DEFAULT 0,
+                        version_id INT NULL,
+                        started_at DATETIME NOT NULL,
+                        ended_at DATETIME NULL,
+                        INDEX idx_upload_checksum (checksum),
+                        INDEX idx_upload_outcome (outcome),
+                        CONSTRAINT fk_upload_version FOREIGN KEY (version_id) REFERENCES catalog_version(id)
+);
\ No newline at end of file
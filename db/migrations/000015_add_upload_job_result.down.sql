@@ -0,0 +1,2 @@
+ALTER TABLE upload_job
+    DROP COLUMN result;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- the outcome of a finished upload, kept as json like the report of a failed one
+ALTER TABLE upload_job
+    ADD COLUMN result TEXT AFTER report;
\ No newline at end of file
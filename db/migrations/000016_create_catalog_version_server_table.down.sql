@@ -0,0 +1,8 @@
+-- only the active version can be kept, its servers move into it
+DELETE FROM server_catalog WHERE id NOT IN (
+    SELECT server_id FROM catalog_version_server WHERE version_id = (SELECT id FROM catalog_version WHERE active = TRUE)
+);
+
+UPDATE server_catalog SET version_id = (SELECT id FROM catalog_version WHERE active = TRUE);
+
+DROP TABLE IF EXISTS catalog_version_server;
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- versions share their servers, a version only adds the servers it inserted or repriced
+CREATE TABLE catalog_version_server (
+                                        version_id INT NOT NULL,
+                                        server_id INT NOT NULL,
+                                        PRIMARY KEY (version_id, server_id),
+                                        INDEX idx_catalog_version_server_server_id (server_id),
+                                        CONSTRAINT fk_catalog_version_server_version FOREIGN KEY (version_id) REFERENCES catalog_version(id),
+                                        CONSTRAINT fk_catalog_version_server_server FOREIGN KEY (server_id) REFERENCES server_catalog(id) ON DELETE CASCADE
+);
+
+-- every server copied so far belongs to the version it was copied into
+INSERT INTO catalog_version_server (version_id, server_id)
+SELECT version_id, id FROM server_catalog;
\ No newline at end of file
@@ -24,27 +24,24 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/servers/hdd-types": {
+        "/admin/currencies": {
             "get": {
                 "security": [
                     {
                         "AppKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a list of all available HDD types in the server catalog",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Currencies the prices of uploaded catalogs can use, by code or symbol",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "servers"
+                    "admin"
                 ],
-                "summary": "Get HDD types",
+                "summary": "List currencies",
                 "responses": {
                     "200": {
-                        "description": "List of HDD types (e.g., SAS, SATA2, SSD)",
+                        "description": "List of currencies",
                         "schema": {
                             "allOf": [
                                 {
@@ -56,16 +53,86 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "type": "string"
+                                                "$ref": "#/definitions/dto.CurrencyResp"
                                             }
                                         }
                                     }
                                 }
                             ]
                         }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Adds a currency, uploads can use its code or symbol right away",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Add a currency",
+                "parameters": [
+                    {
+                        "description": "Currency",
+                        "name": "currency",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CurrencyCtr"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created currency",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CurrencyResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
-                    "422": {
-                        "description": "Unable to fetch HDD types",
+                    "400": {
+                        "description": "Invalid currency",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Currency code or symbol already exists",
                         "schema": {
                             "allOf": [
                                 {
@@ -88,14 +155,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/servers/list": {
+        "/admin/hdd-types": {
             "get": {
                 "security": [
                     {
                         "AppKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a paginated list of servers with optional filtering by storage, RAM, HDD type, and location",
+                "description": "HDD types the HDD column of uploaded catalogs can use",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List HDD types",
+                "responses": {
+                    "200": {
+                        "description": "List of HDD types",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SpecTypeResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Adds an HDD type such as NVMe, uploads can use it right away",
                 "consumes": [
                     "application/json"
                 ],
@@ -103,56 +208,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "servers"
+                    "admin"
                 ],
-                "summary": "Get list of servers",
+                "summary": "Add an HDD type",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Number of items per page (default: 10)",
-                        "name": "per_page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page_no",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Minimum storage (e.g., 1TB)",
-                        "name": "min_storage",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Maximum storage (e.g., 100TB)",
-                        "name": "max_storage",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "RAM values (e.g., 2GB,4GB)",
-                        "name": "ram",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "HDD type (e.g., SATA2, SAS, SSD)",
-                        "name": "hdd_type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Server location (e.g., AmsterdamAMS-01)",
-                        "name": "location",
-                        "in": "query"
+                        "description": "HDD type",
+                        "name": "type",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SpecTypeCtr"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of servers with pagination",
+                    "201": {
+                        "description": "Created HDD type",
                         "schema": {
                             "allOf": [
                                 {
@@ -162,21 +234,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.ListServerResp"
-                                            }
-                                        },
-                                        "pagination": {
-                                            "$ref": "#/definitions/utils.Page"
+                                            "$ref": "#/definitions/dto.SpecTypeResp"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "404": {
-                        "description": "No servers found with the specified filters",
+                    "400": {
+                        "description": "Invalid HDD type",
                         "schema": {
                             "allOf": [
                                 {
@@ -196,8 +262,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "422": {
-                        "description": "Unable to fetch servers",
+                    "409": {
+                        "description": "HDD type already exists",
                         "schema": {
                             "allOf": [
                                 {
@@ -220,27 +286,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/servers/locations": {
+        "/admin/ram-types": {
             "get": {
                 "security": [
                     {
                         "AppKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a list of all available server locations in the catalog",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "RAM types the RAM column of uploaded catalogs can use",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "servers"
+                    "admin"
                 ],
-                "summary": "Get server locations",
+                "summary": "List RAM types",
                 "responses": {
                     "200": {
-                        "description": "List of server locations",
+                        "description": "List of RAM types",
                         "schema": {
                             "allOf": [
                                 {
@@ -252,16 +315,86 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "type": "string"
+                                                "$ref": "#/definitions/dto.SpecTypeResp"
                                             }
                                         }
                                     }
                                 }
                             ]
                         }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Adds a RAM type such as DDR6, uploads can use it right away",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Add a RAM type",
+                "parameters": [
+                    {
+                        "description": "RAM type",
+                        "name": "type",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SpecTypeCtr"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created RAM type",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SpecTypeResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
-                    "422": {
-                        "description": "Unable to fetch locations",
+                    "400": {
+                        "description": "Invalid RAM type",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "RAM type already exists",
                         "schema": {
                             "allOf": [
                                 {
@@ -284,36 +417,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/upload": {
-            "post": {
+        "/jobs/{id}": {
+            "get": {
                 "security": [
                     {
                         "AppKeyAuth": []
                     }
                 ],
-                "description": "Upload a server catalog file in XLSX format. The file must contain valid server catalog data.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Retrieve the state of an asynchronous upload: queued, parsing, inserting, done or failed",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "servers"
+                    "jobs"
                 ],
-                "summary": "Upload server catalog",
+                "summary": "Get upload job",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Server catalog file (XLSX format)",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Catalog uploaded successfully",
+                    "200": {
+                        "description": "Upload job status",
                         "schema": {
                             "allOf": [
                                 {
@@ -322,8 +452,8 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "message": {
-                                            "type": "string"
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UploadJobResp"
                                         }
                                     }
                                 }
@@ -331,7 +461,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid file format or upload failed",
+                        "description": "Invalid job ID",
                         "schema": {
                             "allOf": [
                                 {
@@ -351,8 +481,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "422": {
-                        "description": "Unable to process the file",
+                    "404": {
+                        "description": "Job not found",
                         "schema": {
                             "allOf": [
                                 {
@@ -371,19 +501,1867 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "dto.ListServerResp": {
-            "description": "Server information in the response",
-            "type": "object",
-            "properties": {
-                "hdd": {
+                    },
+                    "422": {
+                        "description": "Unable to fetch job",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/servers/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Upload structured server records as a JSON array or as newline delimited JSON (NDJSON).\nRecords are validated with the same rules as spreadsheet uploads.",
+                "consumes": [
+                    "application/json",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "servers"
+                ],
+                "summary": "Bulk upload servers",
+                "parameters": [
+                    {
+                        "description": "Server records",
+                        "name": "servers",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.BulkServerRecord"
+                            }
+                        }
+                    },
+                    {
+                        "enum": [
+                            "append",
+                            "replace",
+                            "upsert"
+                        ],
+                        "type": "string",
+                        "default": "append",
+                        "description": "Upload mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Store the records even when the body matches the last successful upload",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Servers uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UploadCatalogResp"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed request body",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Body matches the last successful upload",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "413": {
+                        "description": "Body exceeds the upload size limit",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid records, keyed by record and field",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/utils.Errors"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/servers/export": {
+            "get": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Download the servers matching the filters of /servers/list as an XLSX workbook.\nThe workbook has the Model, RAM, HDD, Location and Price header of an upload, so it can be edited and uploaded again unchanged.\nPrices are numeric cells formatted with their currency.",
+                "produces": [
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "servers"
+                ],
+                "summary": "Export servers",
+                "parameters": [
+                    {
+                        "enum": [
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "xlsx",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum storage of all disk groups together (e.g., 1TB)",
+                        "name": "min_storage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum storage of all disk groups together (e.g., 100TB)",
+                        "name": "max_storage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RAM values (e.g., 2GB,4GB,1TB)",
+                        "name": "ram",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RAM type listed by /admin/ram-types (e.g., DDR3, DDR4, DDR5)",
+                        "name": "ram_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only servers with (true) or without (false) ECC memory",
+                        "name": "ecc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HDD type of any disk group listed by /admin/hdd-types (e.g., SATA2, SAS, SSD)",
+                        "name": "hdd_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Server location as uploaded (AmsterdamAMS-01), datacenter code (AMS-01) or city (Amsterdam)",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vendor split from the model (e.g., Dell)",
+                        "name": "vendor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the CPU split from the model (e.g., E5-2650)",
+                        "name": "cpu",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 code of the currency listed by /admin/currencies, only servers priced in it are returned (e.g., EUR)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Minimum price in the given currency, requires currency (e.g., 50)",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Maximum price in the given currency, requires currency (e.g., 100)",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Catalog version (default: the active version)",
+                        "name": "version",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Servers workbook",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format, catalog version, RAM type, HDD type, ECC filter, currency or price range",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unable to export servers",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/servers/hdd-types": {
+            "get": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a list of all available HDD types in the server catalog",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "servers"
+                ],
+                "summary": "Get HDD types",
+                "responses": {
+                    "200": {
+                        "description": "List of HDD types (e.g., SAS, SATA2, SSD)",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unable to fetch HDD types",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/servers/list": {
+            "get": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of servers with optional filtering by storage, RAM, HDD type, location, vendor, CPU and price range",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "servers"
+                ],
+                "summary": "Get list of servers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default: 10)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum storage of all disk groups together (e.g., 1TB)",
+                        "name": "min_storage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum storage of all disk groups together (e.g., 100TB)",
+                        "name": "max_storage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RAM values (e.g., 2GB,4GB,1TB)",
+                        "name": "ram",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RAM type listed by /admin/ram-types (e.g., DDR3, DDR4, DDR5)",
+                        "name": "ram_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only servers with (true) or without (false) ECC memory",
+                        "name": "ecc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HDD type of any disk group listed by /admin/hdd-types (e.g., SATA2, SAS, SSD)",
+                        "name": "hdd_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Server location as uploaded (AmsterdamAMS-01), datacenter code (AMS-01) or city (Amsterdam)",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vendor split from the model (e.g., Dell)",
+                        "name": "vendor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the CPU split from the model (e.g., E5-2650)",
+                        "name": "cpu",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 code of the currency listed by /admin/currencies, only servers priced in it are returned (e.g., EUR)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Minimum price in the given currency, requires currency (e.g., 50)",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "Maximum price in the given currency, requires currency (e.g., 100)",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Catalog version (default: the active version)",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated sort keys: price, ram, storage, model, location. A leading - sorts descending (e.g., -ram,price). Servers with equal keys are ordered by ID",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of servers with pagination",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.ListServerResp"
+                                            }
+                                        },
+                                        "pagination": {
+                                            "$ref": "#/definitions/utils.Page"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid catalog version, RAM type, HDD type, ECC filter, currency, price range or sort key",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "No servers found with the specified filters",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unable to fetch servers",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/servers/locations": {
+            "get": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the locations of the active catalog split into city, datacenter code and country",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "servers"
+                ],
+                "summary": "Get server locations",
+                "responses": {
+                    "200": {
+                        "description": "List of server locations",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.LocationResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unable to fetch locations",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/upload": {
+            "post": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Upload a server catalog file in XLSX, CSV or TSV format. The file must contain valid server catalog data.\nThe format is detected from the leading bytes of the file and its content type.\nColumns are matched by header name in any order, the header aliases configured in upload.columns are accepted and extra columns are ignored.\nPrices carry a currency symbol or ISO 4217 code before or after the amount ($35.99, 39,99 €, EUR 1.299,00), numeric XLSX cells take it from their number format.\nAmounts such as 1,299 that read differently depending on the locale are rejected.\nWith dry_run=true the file is only validated, see /upload/preview.\nWith async=true the upload is queued and its progress can be polled at /jobs/{id}.\nEvery upload is recorded in the upload history, see /uploads. A file matching the last successful upload is rejected unless force=true.\nThe mode decides what happens to the servers already in the catalog: append inserts every row,\nreplace swaps the whole catalog and upsert updates the price of servers with the same model, RAM, HDD and location.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "servers"
+                ],
+                "summary": "Upload server catalog",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Server catalog file (XLSX, CSV or TSV format)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workbook sheets to import: a name, a comma separated list or * for every sheet with a catalog header (default: the first sheet)",
+                        "name": "sheet",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "append",
+                            "replace",
+                            "upsert"
+                        ],
+                        "type": "string",
+                        "default": "append",
+                        "description": "Upload mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the file without storing it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Process the upload in the background",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Store the file even when it matches the last successful upload",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Catalog uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UploadCatalogResp"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Upload queued",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UploadJobResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file format or upload failed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "File matches the last successful upload",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "413": {
+                        "description": "File exceeds the upload size limit",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid catalog rows, keyed by cell reference (Sheet!Cell when sheets were selected)",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/utils.Errors"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Upload queue is full",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/upload/diff": {
+            "post": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Parse and validate a server catalog file and compare it against the active catalog without storing it.\nReports added servers, removed servers, price changes and spec changes.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "servers"
+                ],
+                "summary": "Diff server catalog upload",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Server catalog file (XLSX, CSV or TSV format)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workbook sheets to import: a name, a comma separated list or * for every sheet with a catalog header (default: the first sheet)",
+                        "name": "sheet",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Catalog diff",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CatalogDiffResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file or format",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "413": {
+                        "description": "File exceeds the upload size limit",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid catalog rows, keyed by cell reference (Sheet!Cell when sheets were selected)",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/utils.Errors"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/upload/preview": {
+            "post": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Parse and validate a server catalog file without storing it. Returns the normalized rows, the lookup IDs they resolve to and any warnings.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "servers"
+                ],
+                "summary": "Preview server catalog upload",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Server catalog file (XLSX, CSV or TSV format)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workbook sheets to import: a name, a comma separated list or * for every sheet with a catalog header (default: the first sheet)",
+                        "name": "sheet",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rows as they would be stored",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CatalogPreviewResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file format",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "413": {
+                        "description": "File exceeds the upload size limit",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid catalog rows, keyed by cell reference (Sheet!Cell when sheets were selected)",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/utils.Errors"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads": {
+            "get": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the upload history, newest first: the file or bulk request every upload came from, the App-key that sent it and how it ended",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "List uploads",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default: 10)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page_no",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Upload history with pagination",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.UploadResp"
+                                            }
+                                        },
+                                        "pagination": {
+                                            "$ref": "#/definitions/utils.Page"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unable to fetch uploads",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/versions": {
+            "get": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve every catalog version, newest first. Each successful upload creates a new version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versions"
+                ],
+                "summary": "List catalog versions",
+                "responses": {
+                    "200": {
+                        "description": "Catalog versions",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CatalogVersionResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unable to fetch versions",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/versions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Compare two catalog versions and report added servers, removed servers, price changes and spec changes.\nServers are matched on model, RAM, HDD and location.",
+                "produces": [
+                    "application/json",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "versions"
+                ],
+                "summary": "Diff catalog versions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Version compared against",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Compared version (default: the active version)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Catalog diff",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CatalogDiffResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid version or format",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Version not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unable to diff versions",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/versions/{id}/activate": {
+            "post": {
+                "security": [
+                    {
+                        "AppKeyAuth": []
+                    }
+                ],
+                "description": "Make a catalog version the active one. Activating an older version rolls back the uploads made after it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versions"
+                ],
+                "summary": "Activate catalog version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Version ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Activated version",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CatalogVersionResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid version ID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Version not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unable to activate version",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.BulkDiskRecord": {
+            "description": "Disk group, e.g. 2x120GBSSD",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "size_gb": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "type": {
+                    "type": "string",
+                    "example": "SSD"
+                }
+            }
+        },
+        "dto.BulkServerRecord": {
+            "description": "Structured server record",
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "disk_count": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "disk_size_gb": {
+                    "type": "integer",
+                    "example": 1024
+                },
+                "disk_type": {
+                    "type": "string",
+                    "example": "SATA2"
+                },
+                "disks": {
+                    "description": "Disks describes mixed configurations and takes precedence over the single disk fields",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BulkDiskRecord"
+                    }
+                },
+                "location": {
+                    "type": "string",
+                    "example": "AmsterdamAMS-01"
+                },
+                "model": {
+                    "type": "string",
+                    "example": "HP DL120G7Intel G850"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 39.99
+                },
+                "ram_ecc": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "ram_gb": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "ram_speed": {
+                    "type": "integer",
+                    "example": 2666
+                },
+                "ram_type": {
+                    "type": "string",
+                    "example": "DDR3"
+                }
+            }
+        },
+        "dto.CatalogDiffResp": {
+            "description": "Servers added, removed, repriced and respecced between two catalogs",
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ListServerResp"
+                    }
+                },
+                "from_version": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "price_changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PriceChangeResp"
+                    }
+                },
+                "removed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ListServerResp"
+                    }
+                },
+                "spec_changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SpecChangeResp"
+                    }
+                },
+                "to_version": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "dto.CatalogPreviewDisk": {
+            "description": "Disk group and the HDD type it resolves to",
+            "type": "object",
+            "properties": {
+                "hdd_count": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "hdd_size": {
+                    "type": "integer",
+                    "example": 1024
+                },
+                "hdd_type_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.CatalogPreviewResp": {
+            "description": "Rows of an uploaded catalog as they would be stored",
+            "type": "object",
+            "properties": {
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CatalogPreviewRow"
+                    }
+                },
+                "sheets": {
+                    "description": "Sheets is only set when sheets were selected",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SheetResult"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "row 7 duplicates the server of row 3"
+                    ]
+                }
+            }
+        },
+        "dto.CatalogPreviewRow": {
+            "description": "Normalized catalog row",
+            "type": "object",
+            "properties": {
+                "currency_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "disks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CatalogPreviewDisk"
+                    }
+                },
+                "location": {
+                    "type": "string",
+                    "example": "AmsterdamAMS-01"
+                },
+                "model": {
                     "type": "string",
-                    "example": "4x1TBSATA2"
+                    "example": "HP DL120G7Intel G850"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 39.99
+                },
+                "ram_ecc": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "ram_size": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "ram_speed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "ram_type_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sheet": {
+                    "type": "string",
+                    "example": "EU"
+                },
+                "storage": {
+                    "type": "integer",
+                    "example": 4096
+                }
+            }
+        },
+        "dto.CatalogVersionResp": {
+            "description": "Immutable catalog version created by an upload",
+            "type": "object",
+            "properties": {
+                "activated_at": {
+                    "type": "string"
+                },
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "replace"
+                },
+                "server_count": {
+                    "type": "integer",
+                    "example": 486
+                }
+            }
+        },
+        "dto.CurrencyCtr": {
+            "description": "Currency prices can be given in",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "GBP"
+                },
+                "decimal_separator": {
+                    "type": "string",
+                    "enum": [
+                        ".",
+                        "",
+                        ""
+                    ],
+                    "example": "."
+                },
+                "decimals": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Pound Sterling"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "£"
+                },
+                "symbol_position": {
+                    "description": "the formatting rules default to $1299.00",
+                    "type": "string",
+                    "enum": [
+                        "before",
+                        "after"
+                    ],
+                    "example": "before"
+                },
+                "thousands_separator": {
+                    "type": "string",
+                    "example": ","
+                }
+            }
+        },
+        "dto.CurrencyResp": {
+            "description": "Currency prices can be given in",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "GBP"
+                },
+                "decimal_separator": {
+                    "type": "string",
+                    "example": "."
+                },
+                "decimals": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "example": {
+                    "type": "string",
+                    "example": "£1,234.50"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Pound Sterling"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "£"
+                },
+                "symbol_position": {
+                    "type": "string",
+                    "example": "before"
+                },
+                "thousands_separator": {
+                    "type": "string",
+                    "example": ","
+                }
+            }
+        },
+        "dto.ListServerResp": {
+            "description": "Server information in the response",
+            "type": "object",
+            "properties": {
+                "chassis": {
+                    "type": "string",
+                    "example": "DL120G7"
+                },
+                "cpu": {
+                    "type": "string",
+                    "example": "Intel G850"
+                },
+                "hdd": {
+                    "type": "string",
+                    "example": "2x120GBSSD+4x1TBSATA2"
                 },
                 "location": {
                     "type": "string",
@@ -398,11 +2376,331 @@ const docTemplate = `{
                     "example": "€39.99"
                 },
                 "ram": {
+                    "type": "string",
+                    "example": "64GBDDR4-2666 ECC"
+                },
+                "vendor": {
+                    "type": "string",
+                    "example": "HP"
+                }
+            }
+        },
+        "dto.LocationResp": {
+            "description": "Location split into its city, datacenter code and country",
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "Amsterdam"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "AMS-01"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "Netherlands"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "AmsterdamAMS-01"
+                }
+            }
+        },
+        "dto.PriceChangeResp": {
+            "description": "Old and new price of a server",
+            "type": "object",
+            "properties": {
+                "delta_percent": {
+                    "description": "DeltaPercent is not set when the currency changed",
+                    "type": "number",
+                    "example": -10
+                },
+                "hdd": {
+                    "type": "string",
+                    "example": "4x1TBSATA2"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "AmsterdamAMS-01"
+                },
+                "model": {
+                    "type": "string",
+                    "example": "HP DL120G7Intel G850"
+                },
+                "new_price": {
+                    "type": "string",
+                    "example": "€35.99"
+                },
+                "old_price": {
+                    "type": "string",
+                    "example": "€39.99"
+                },
+                "ram": {
+                    "type": "string",
+                    "example": "4GBDDR3"
+                }
+            }
+        },
+        "dto.SheetResult": {
+            "description": "Servers read from a sheet, or why the sheet was skipped",
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "EU"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "invalid XLSX columns: missing Model (accepted: Model)"
+                },
+                "servers": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "skipped": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.SpecChangeResp": {
+            "description": "Old and new configuration of a server",
+            "type": "object",
+            "properties": {
+                "location": {
+                    "type": "string",
+                    "example": "AmsterdamAMS-01"
+                },
+                "model": {
+                    "type": "string",
+                    "example": "HP DL120G7Intel G850"
+                },
+                "new_hdd": {
+                    "type": "string",
+                    "example": "4x1TBSATA2"
+                },
+                "new_price": {
+                    "type": "string",
+                    "example": "€39.99"
+                },
+                "new_ram": {
+                    "type": "string",
+                    "example": "8GBDDR3"
+                },
+                "old_hdd": {
+                    "type": "string",
+                    "example": "4x1TBSATA2"
+                },
+                "old_price": {
+                    "type": "string",
+                    "example": "€39.99"
+                },
+                "old_ram": {
                     "type": "string",
                     "example": "4GBDDR3"
                 }
             }
         },
+        "dto.SpecTypeCtr": {
+            "description": "HDD or RAM type as written in uploaded catalogs",
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "example": "NVMe"
+                }
+            }
+        },
+        "dto.SpecTypeResp": {
+            "description": "HDD or RAM type",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "type": {
+                    "type": "string",
+                    "example": "NVMe"
+                }
+            }
+        },
+        "dto.UploadCatalogResp": {
+            "description": "Number of servers affected by an upload",
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "inserted": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "upsert"
+                },
+                "sheets": {
+                    "description": "Sheets is only set when sheets were selected",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SheetResult"
+                    }
+                },
+                "unchanged": {
+                    "type": "integer",
+                    "example": 471
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "upload": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "dto.UploadJobResp": {
+            "description": "Asynchronous upload job status",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "report": {
+                    "$ref": "#/definitions/utils.Errors"
+                },
+                "result": {
+                    "$ref": "#/definitions/dto.UploadCatalogResp"
+                },
+                "rows_processed": {
+                    "type": "integer",
+                    "example": 1500
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "queued",
+                        "parsing",
+                        "inserting",
+                        "done",
+                        "failed"
+                    ],
+                    "example": "inserting"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UploadResp": {
+            "description": "Provenance and outcome of a catalog upload",
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "deleted": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "ended_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string",
+                    "example": "servers_filters_assignment.xlsx"
+                },
+                "forced": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "inserted": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "upsert"
+                },
+                "outcome": {
+                    "type": "string",
+                    "enum": [
+                        "running",
+                        "succeeded",
+                        "failed",
+                        "duplicate"
+                    ],
+                    "example": "succeeded"
+                },
+                "rows": {
+                    "type": "integer",
+                    "example": 486
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 20480
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "file",
+                        "bulk"
+                    ],
+                    "example": "file"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "unchanged": {
+                    "type": "integer",
+                    "example": 471
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "uploader": {
+                    "type": "string",
+                    "example": "app-key:1a2b3c4d5e6f"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "utils.Errors": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "array",
+                "items": {
+                    "type": "string"
+                }
+            }
+        },
         "utils.Page": {
             "description": "Pagination details",
             "type": "object",
@@ -1,6 +1,9 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"github.com/spf13/viper"
+	"time"
+)
 
 // represents environment level
 const (
@@ -16,6 +19,9 @@ type Application struct {
 	Env             string
 	PaginationLimit int
 	SecretKey       string
+	// LookupRefresh is how often the spec types and currencies are reloaded so
+	// changes made through other instances are picked up, 0 disables it
+	LookupRefresh time.Duration
 }
 
 var app Application
@@ -35,5 +41,6 @@ func LoadApp() {
 		Env:             viper.GetString("app.env"),
 		PaginationLimit: viper.GetInt("app.pagination_limit"),
 		SecretKey:       viper.GetString("app.secret_key"),
+		LookupRefresh:   viper.GetDuration("app.lookup_refresh"),
 	}
 }
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"github.com/spf13/viper"
+	"io/fs"
 	"sync"
 )
 
@@ -12,12 +14,23 @@ var mu sync.Mutex
 func LoadConfig() error {
 	viper.SetConfigFile("config.example.yml") // local
 
-	if err := viper.ReadInConfig(); err != nil {
-		fmt.Errorf("error reading config file: %s", err)
+	// a missing file leaves the defaults and whatever viper gets from elsewhere,
+	// only a file that can't be read or parsed stops the startup
+	if err := viper.ReadInConfig(); err != nil && !configFileMissing(err) {
+		return fmt.Errorf("error reading config file: %s", err)
 	}
 
 	LoadApp()
 	LoadDB()
+	if err := LoadUpload(); err != nil {
+		return err
+	}
 
 	return nil
 }
+
+// configFileMissing reports whether err only says that the config file doesn't exist
+func configFileMissing(err error) bool {
+	var notFound viper.ConfigFileNotFoundError
+	return errors.As(err, &notFound) || errors.Is(err, fs.ErrNotExist)
+}
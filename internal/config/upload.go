@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"time"
+)
+
+// UploadPolicy holds the catalog upload policy
+type UploadPolicy struct {
+	MaxRows   int    // maximum number of servers in a single upload, 0 disables the limit
+	MaxBytes  int64  // maximum size of an uploaded request body in bytes, 0 disables the limit
+	BatchSize int    // number of servers inserted per database round trip
+	SpoolDir  string // directory uploaded files are spooled to, defaults to the system temp dir
+
+	// UnzipSizeLimit caps the uncompressed size of an uploaded workbook in bytes and
+	// UnzipXMLSizeLimit the size of a worksheet unzipped into memory, larger worksheets
+	// are unzipped to temporary files. 0 keeps the excelize defaults.
+	UnzipSizeLimit    int64
+	UnzipXMLSizeLimit int64
+
+	// Columns lists the header names accepted next to the canonical ones,
+	// keyed by the lower case canonical column name (model, ram, hdd, location, price)
+	Columns map[string][]string
+
+	// Models splits the model strings into vendor, chassis and CPU
+	Models ModelRules
+
+	// Countries maps the city part of the datacenter codes to their country,
+	// keyed by the lower case city code (ams for AMS-01)
+	Countries map[string]string
+
+	Workers      int           // number of asynchronous upload jobs processed concurrently
+	QueueSize    int           // number of asynchronous upload jobs waiting for a worker
+	DrainTimeout time.Duration // how long shutdown waits for pending upload jobs
+}
+
+// ModelRules splits run-together model strings such as "HP DL120G7Intel G850"
+type ModelRules struct {
+	// CPUVendors start the CPU part of a model, optionally preceded by the CPU count (2x)
+	CPUVendors []string `mapstructure:"cpu_vendors"`
+	// Vendors are recognised by their name or by the chassis prefix of their product lines
+	Vendors []VendorRule `mapstructure:"vendors"`
+}
+
+// VendorRule recognises a server vendor
+type VendorRule struct {
+	Name string `mapstructure:"name"`
+	// Prefixes start the chassis names of the vendor when it isn't named, e.g. DL for HP DL120G7
+	Prefixes []string `mapstructure:"prefixes"`
+}
+
+var upload UploadPolicy
+
+// Upload returns the catalog upload policy
+func Upload() *UploadPolicy {
+	return &upload
+}
+
+// LoadUpload loads the catalog upload policy
+func LoadUpload() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	upload = UploadPolicy{
+		MaxRows:   viper.GetInt("upload.max_rows"),
+		MaxBytes:  viper.GetInt64("upload.max_bytes"),
+		BatchSize: viper.GetInt("upload.batch_size"),
+		SpoolDir:  viper.GetString("upload.spool_dir"),
+		Columns:   viper.GetStringMapStringSlice("upload.columns"),
+		Countries: viper.GetStringMapString("upload.countries"),
+
+		UnzipSizeLimit:    viper.GetInt64("upload.unzip_size_limit"),
+		UnzipXMLSizeLimit: viper.GetInt64("upload.unzip_xml_size_limit"),
+
+		Workers:      viper.GetInt("upload.workers"),
+		QueueSize:    viper.GetInt("upload.queue_size"),
+		DrainTimeout: viper.GetDuration("upload.drain_timeout"),
+	}
+	if err := viper.UnmarshalKey("upload.models", &upload.Models); err != nil {
+		return fmt.Errorf("invalid upload.models: %s", err)
+	}
+	return nil
+}
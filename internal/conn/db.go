@@ -36,6 +36,7 @@ var newLogger = logger.New(
 func Connect(cfg *config.Database) error {
 	uri := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=True", cfg.Username, cfg.Password, cfg.Host, cfg.Port, cfg.Name)
 
+	// single writes aren't wrapped in a transaction, uploads open an explicit one
 	gormDB, err := gorm.Open(mysql.Open(uri), &gorm.Config{
 		SkipDefaultTransaction: true,
 		Logger:                 newLogger,
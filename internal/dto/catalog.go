@@ -2,12 +2,94 @@ package dto
 
 import (
 	"github.com/server-catalog/internal/utils"
+	"io"
 	"mime/multipart"
 )
 
+// Upload modes decide what happens to the servers already in the catalog
+const (
+	// UploadModeAppend inserts every server next to the existing ones
+	UploadModeAppend = "append"
+	// UploadModeReplace swaps the whole catalog for the uploaded servers
+	UploadModeReplace = "replace"
+	// UploadModeUpsert updates the price of known servers and inserts the others
+	UploadModeUpsert = "upsert"
+)
+
+// AllSheets selects every sheet of a workbook that starts with a catalog header
+const AllSheets = "*"
+
 // UploadCatalogCtr ...
 type UploadCatalogCtr struct {
-	File multipart.File
+	File        multipart.File
+	Filename    string
+	ContentType string
+	Mode        string
+	// Uploader identifies the App-key the file was uploaded with
+	Uploader string
+	// Force stores the file even when it matches the last successful upload
+	Force bool
+	// Sheets lists the workbook sheets to import, the first sheet is imported when it is empty
+	Sheets []string
+	// Progress is called with the current stage and the number of rows it processed so far
+	Progress func(stage string, rows int)
+}
+
+// BulkUploadCtr ...
+type BulkUploadCtr struct {
+	Body     io.Reader
+	Mode     string
+	Uploader string
+	Force    bool
+}
+
+// UploadCatalogResp represents the outcome of an upload
+// @Description Number of servers affected by an upload
+type UploadCatalogResp struct {
+	Upload    uint   `json:"upload" example:"12" description:"Upload recorded in the upload history"`
+	Version   uint   `json:"version" example:"7" description:"Catalog version created by the upload"`
+	Mode      string `json:"mode" example:"upsert"`
+	Inserted  int    `json:"inserted" example:"12"`
+	Updated   int    `json:"updated" example:"3"`
+	Unchanged int    `json:"unchanged" example:"471"`
+	Deleted   int    `json:"deleted" example:"0"`
+	// Sheets is only set when sheets were selected
+	Sheets []SheetResult `json:"sheets,omitempty"`
+}
+
+// SheetResult represents the outcome of a single sheet of a workbook
+// @Description Servers read from a sheet, or why the sheet was skipped
+type SheetResult struct {
+	Name    string `json:"name" example:"EU"`
+	Servers int    `json:"servers" example:"120"`
+	Skipped bool   `json:"skipped" example:"false"`
+	Reason  string `json:"reason,omitempty" example:"invalid XLSX columns: missing Model (accepted: Model)"`
+}
+
+// BulkServerRecord represents a single server of a bulk upload
+// @Description Structured server record
+type BulkServerRecord struct {
+	Model      string  `json:"model" example:"HP DL120G7Intel G850"`
+	RamGB      int     `json:"ram_gb" example:"4"`
+	RamType    string  `json:"ram_type" example:"DDR3"`
+	DiskCount  int     `json:"disk_count" example:"4"`
+	DiskSizeGB int     `json:"disk_size_gb" example:"1024"`
+	DiskType   string  `json:"disk_type" example:"SATA2"`
+	Location   string  `json:"location" example:"AmsterdamAMS-01"`
+	Price      float64 `json:"price" example:"39.99"`
+	Currency   string  `json:"currency" example:"EUR" description:"ISO 4217 code or currency symbol"`
+	// Disks describes mixed configurations and takes precedence over the single disk fields
+	Disks    []BulkDiskRecord `json:"disks,omitempty"`
+	RamECC   bool             `json:"ram_ecc,omitempty" example:"true"`
+	RamSpeed int              `json:"ram_speed,omitempty" example:"2666" description:"Module speed in MT/s"`
+}
+
+// BulkDiskRecord represents a group of identical disks of a bulk server record
+// @Description Disk group, e.g. 2x120GBSSD
+type BulkDiskRecord struct {
+	Count  int    `json:"count" example:"2"`
+	SizeGB int    `json:"size_gb" example:"120"`
+	Type   string `json:"type" example:"SSD"`
 }
 
 // ListServersCtr ...
@@ -15,17 +97,76 @@ type ListServersCtr struct {
 	StorageMin *int
 	StorageMax *int
 	RAM        []int
+	RAMType    *int
+	ECC        *bool
 	HDD        *int
 	Location   *string
-	Page       *utils.Page `json:"page"`
+	Vendor     *string
+	CPU        *string
+	// Currency restricts the list to prices in one currency, MinPrice and MaxPrice
+	// are only given along with it so amounts of different currencies are never compared
+	Currency *int
+	MinPrice *float64
+	MaxPrice *float64
+	// Version selects a catalog version, the active one is used when it is nil
+	Version *uint
+	// Sort orders the list, servers with equal keys are ordered by ID
+	Sort []utils.SortKey
+	Page *utils.Page `json:"page"`
 }
 
 // ListServerResp represents the server information in the response
 // @Description Server information in the response
 type ListServerResp struct {
 	Model    string `json:"model" example:"HP DL120G7Intel G850" description:"Server model name"`
-	Ram      string `json:"ram" example:"4GBDDR3" description:"RAM configuration (size and type)"`
-	HDD      string `json:"hdd" example:"4x1TBSATA2" description:"Hard disk configuration (count, size and type)"`
+	Vendor   string `json:"vendor" example:"HP" description:"Vendor split from the model, empty when unknown"`
+	Chassis  string `json:"chassis" example:"DL120G7" description:"Chassis split from the model"`
+	CPU      string `json:"cpu" example:"Intel G850" description:"CPU split from the model"`
+	Ram      string `json:"ram" example:"64GBDDR4-2666 ECC" description:"RAM configuration (size, type, speed and ECC)"`
+	HDD      string `json:"hdd" example:"2x120GBSSD+4x1TBSATA2" description:"Hard disk configuration (count, size and type of every disk group)"`
 	Location string `json:"location" example:"AmsterdamAMS-01" description:"Server location code"`
 	Price    string `json:"price" example:"€39.99" description:"Server price with currency symbol"`
 }
+
+// LocationResp represents a location of the catalog
+// @Description Location split into its city, datacenter code and country
+type LocationResp struct {
+	Name    string `json:"name" example:"AmsterdamAMS-01" description:"Location as uploaded"`
+	City    string `json:"city" example:"Amsterdam"`
+	Code    string `json:"code" example:"AMS-01" description:"Datacenter code, empty when the location has none"`
+	Country string `json:"country" example:"Netherlands" description:"Country of the datacenter, empty when unknown"`
+}
+
+// CatalogPreviewResp represents the result of a dry-run upload
+// @Description Rows of an uploaded catalog as they would be stored
+type CatalogPreviewResp struct {
+	Rows     []CatalogPreviewRow `json:"rows"`
+	Warnings []string            `json:"warnings" example:"row 7 duplicates the server of row 3"`
+	// Sheets is only set when sheets were selected
+	Sheets []SheetResult `json:"sheets,omitempty"`
+}
+
+// CatalogPreviewRow represents a validated catalog row and the lookup IDs it resolves to
+// @Description Normalized catalog row
+type CatalogPreviewRow struct {
+	Sheet      string               `json:"sheet,omitempty" example:"EU" description:"Sheet of the row, set when sheets were selected"`
+	Row        int                  `json:"row" example:"2" description:"Row number in the uploaded sheet"`
+	Model      string               `json:"model" example:"HP DL120G7Intel G850"`
+	RamSize    int                  `json:"ram_size" example:"4" description:"RAM size in GB"`
+	RamTypeID  int                  `json:"ram_type_id" example:"1"`
+	RamECC     bool                 `json:"ram_ecc" example:"false"`
+	RamSpeed   int                  `json:"ram_speed" example:"0" description:"Module speed in MT/s, 0 when unknown"`
+	Disks      []CatalogPreviewDisk `json:"disks"`
+	Storage    int                  `json:"storage" example:"4096" description:"Capacity of every disk in GB"`
+	Location   string               `json:"location" example:"AmsterdamAMS-01"`
+	Price      float64              `json:"price" example:"39.99"`
+	CurrencyID int                  `json:"currency_id" example:"2"`
+}
+
+// CatalogPreviewDisk represents a disk group of a validated catalog row
+// @Description Disk group and the HDD type it resolves to
+type CatalogPreviewDisk struct {
+	HDDCount  int `json:"hdd_count" example:"4"`
+	HDDSize   int `json:"hdd_size" example:"1024" description:"Size of a single disk in GB"`
+	HDDTypeID int `json:"hdd_type_id" example:"1"`
+}
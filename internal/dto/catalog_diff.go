@@ -0,0 +1,38 @@
+package dto
+
+// CatalogDiffResp represents the changes between two catalogs
+// @Description Servers added, removed, repriced and respecced between two catalogs
+type CatalogDiffResp struct {
+	FromVersion  uint              `json:"from_version" example:"6" description:"Catalog version compared against"`
+	ToVersion    uint              `json:"to_version,omitempty" example:"7" description:"Compared catalog version, empty for an uploaded file"`
+	Added        []ListServerResp  `json:"added"`
+	Removed      []ListServerResp  `json:"removed"`
+	PriceChanges []PriceChangeResp `json:"price_changes"`
+	SpecChanges  []SpecChangeResp  `json:"spec_changes"`
+}
+
+// PriceChangeResp represents a server whose price changed
+// @Description Old and new price of a server
+type PriceChangeResp struct {
+	Model    string `json:"model" example:"HP DL120G7Intel G850"`
+	Ram      string `json:"ram" example:"4GBDDR3"`
+	HDD      string `json:"hdd" example:"4x1TBSATA2"`
+	Location string `json:"location" example:"AmsterdamAMS-01"`
+	OldPrice string `json:"old_price" example:"€39.99"`
+	NewPrice string `json:"new_price" example:"€35.99"`
+	// DeltaPercent is not set when the currency changed
+	DeltaPercent *float64 `json:"delta_percent,omitempty" example:"-10"`
+}
+
+// SpecChangeResp represents a server whose RAM or HDD changed at the same model and location
+// @Description Old and new configuration of a server
+type SpecChangeResp struct {
+	Model    string `json:"model" example:"HP DL120G7Intel G850"`
+	Location string `json:"location" example:"AmsterdamAMS-01"`
+	OldRam   string `json:"old_ram" example:"4GBDDR3"`
+	NewRam   string `json:"new_ram" example:"8GBDDR3"`
+	OldHDD   string `json:"old_hdd" example:"4x1TBSATA2"`
+	NewHDD   string `json:"new_hdd" example:"4x1TBSATA2"`
+	OldPrice string `json:"old_price" example:"€39.99"`
+	NewPrice string `json:"new_price" example:"€39.99"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// CatalogVersionResp represents a catalog version
+// @Description Immutable catalog version created by an upload
+type CatalogVersionResp struct {
+	ID          uint       `json:"id" example:"7"`
+	Mode        string     `json:"mode" example:"replace" description:"Upload mode that created the version"`
+	ServerCount int        `json:"server_count" example:"486"`
+	Active      bool       `json:"active" example:"true"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ActivatedAt *time.Time `json:"activated_at,omitempty"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+// SpecTypeCtr holds a new HDD or RAM type
+// @Description HDD or RAM type as written in uploaded catalogs
+type SpecTypeCtr struct {
+	Type string `json:"type" example:"NVMe"`
+}
+
+// SpecTypeResp represents an HDD or RAM type
+// @Description HDD or RAM type
+type SpecTypeResp struct {
+	ID   uint   `json:"id" example:"4"`
+	Type string `json:"type" example:"NVMe"`
+}
+
+// CurrencyCtr holds a new currency
+// @Description Currency prices can be given in
+type CurrencyCtr struct {
+	Code   string `json:"code" example:"GBP" description:"ISO 4217 code"`
+	Name   string `json:"name" example:"Pound Sterling"`
+	Symbol string `json:"symbol" example:"£"`
+	// the formatting rules default to $1299.00
+	SymbolPosition     string `json:"symbol_position" example:"before" enums:"before,after" description:"Symbol before the amount or after it separated by a space, defaults to before"`
+	Decimals           *int   `json:"decimals" example:"2" description:"Decimal places from 0 to 2, defaults to 2"`
+	DecimalSeparator   string `json:"decimal_separator" example:"." enums:".,," description:"Defaults to ."`
+	ThousandsSeparator string `json:"thousands_separator" example:"," description:"Groups the digits of the amount, empty to not group them"`
+}
+
+// CurrencyResp represents a currency
+// @Description Currency prices can be given in
+type CurrencyResp struct {
+	ID                 uint   `json:"id" example:"4"`
+	Code               string `json:"code" example:"GBP" description:"ISO 4217 code"`
+	Name               string `json:"name" example:"Pound Sterling"`
+	Symbol             string `json:"symbol" example:"£"`
+	SymbolPosition     string `json:"symbol_position" example:"before"`
+	Decimals           int    `json:"decimals" example:"2"`
+	DecimalSeparator   string `json:"decimal_separator" example:"."`
+	ThousandsSeparator string `json:"thousands_separator" example:","`
+	Example            string `json:"example" example:"£1,234.50" description:"1234.5 rendered with the rules of the currency"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// UploadResp represents an entry of the upload history
+// @Description Provenance and outcome of a catalog upload
+type UploadResp struct {
+	ID        uint       `json:"id" example:"12"`
+	Source    string     `json:"source" example:"file" enums:"file,bulk"`
+	Filename  string     `json:"filename,omitempty" example:"servers_filters_assignment.xlsx" description:"Original filename, empty for bulk uploads"`
+	Size      int64      `json:"size" example:"20480" description:"Size of the uploaded content in bytes"`
+	Checksum  string     `json:"checksum" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08" description:"SHA-256 of the uploaded content"`
+	Uploader  string     `json:"uploader" example:"app-key:1a2b3c4d5e6f" description:"Identity of the App-key used for the upload"`
+	Mode      string     `json:"mode" example:"upsert"`
+	Forced    bool       `json:"forced" example:"false" description:"Stored although it matched the last successful upload"`
+	Outcome   string     `json:"outcome" example:"succeeded" enums:"running,succeeded,failed,duplicate"`
+	Error     string     `json:"error,omitempty" description:"Reason the upload failed or was rejected"`
+	Rows      int        `json:"rows" example:"486" description:"Servers read from the upload"`
+	Inserted  int        `json:"inserted" example:"12"`
+	Updated   int        `json:"updated" example:"3"`
+	Unchanged int        `json:"unchanged" example:"471"`
+	Deleted   int        `json:"deleted" example:"0"`
+	Version   *uint      `json:"version,omitempty" example:"7" description:"Catalog version created by the upload"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"github.com/server-catalog/internal/utils"
+	"time"
+)
+
+// UploadJobResp represents the status of an asynchronous upload
+// @Description Asynchronous upload job status
+type UploadJobResp struct {
+	ID            uint               `json:"id" example:"42"`
+	State         string             `json:"state" example:"inserting" enums:"queued,parsing,inserting,done,failed"`
+	RowsProcessed int                `json:"rows_processed" example:"1500" description:"Rows processed so far in the current state"`
+	Error         string             `json:"error,omitempty" description:"Reason the job failed"`
+	Report        utils.Errors       `json:"report,omitempty" description:"Invalid catalog rows, keyed by cell reference"`
+	Result        *UploadCatalogResp `json:"result,omitempty" description:"Outcome of the upload once the job is done"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
@@ -0,0 +1,216 @@
+package lookup
+
+import (
+	"context"
+	"fmt"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Source loads the rows of the lookup tables
+type Source interface {
+	GetHDDSpecs(ctx context.Context) ([]models.HDDSpec, error)
+	GetRAMSpecs(ctx context.Context) ([]models.RamSpec, error)
+	GetCurrencies(ctx context.Context) ([]models.Currency, error)
+}
+
+// Tables holds the rows of the hdd_spec, ram_spec and currency tables
+type Tables struct {
+	HDDSpecs   []models.HDDSpec
+	RAMSpecs   []models.RamSpec
+	Currencies []models.Currency
+}
+
+// Seed holds the rows created by the migrations
+var Seed = Tables{
+	HDDSpecs: []models.HDDSpec{
+		{ID: utils.HDDTypeSATA2, Type: "SATA2"},
+		{ID: utils.HDDTypeSAS, Type: "SAS"},
+		{ID: utils.HDDTypeSSD, Type: "SSD"},
+	},
+	RAMSpecs: []models.RamSpec{
+		{ID: utils.RAMTypeDDR3, Type: "DDR3"},
+		{ID: utils.RAMTypeDDR4, Type: "DDR4"},
+		{ID: utils.RAMTypeDDR5, Type: "DDR5"},
+	},
+	Currencies: []models.Currency{
+		{ID: utils.CurrencyUSD, Code: utils.CurrencyCodeUSD, Type: "USD", Symbol: utils.CurrencySymbolUSD,
+			SymbolPosition: models.SymbolBefore, Decimals: 2, DecimalSeparator: "."},
+		{ID: utils.CurrencyEuro, Code: utils.CurrencyCodeEuro, Type: "Euro", Symbol: utils.CurrencySymbolEuro,
+			SymbolPosition: models.SymbolBefore, Decimals: 2, DecimalSeparator: "."},
+		{ID: utils.CurrencySGD, Code: utils.CurrencyCodeSGD, Type: "Singapore Dollar", Symbol: utils.CurrencySymbolSGD,
+			SymbolPosition: models.SymbolBefore, Decimals: 2, DecimalSeparator: "."},
+	},
+}
+
+// Registry caches the lookup tables used to parse, filter and render the catalog.
+// Names and codes are matched case-insensitively, symbols exactly.
+type Registry struct {
+	source Source
+
+	mu         sync.RWMutex
+	tables     Tables
+	hddIDs     map[string]int
+	hddNames   map[int]string
+	ramIDs     map[string]int
+	ramNames   map[int]string
+	codes      map[string]int
+	symbols    map[string]int
+	currencies map[int]models.Currency
+}
+
+// New returns an empty registry loading the tables from source, call Refresh to load them
+func New(source Source) *Registry {
+	r := &Registry{source: source}
+	r.Set(Tables{})
+	return r
+}
+
+// Static returns a registry serving the given tables that is never refreshed
+func Static(tables Tables) *Registry {
+	r := New(nil)
+	r.Set(tables)
+	return r
+}
+
+// Refresh reloads the tables from the source, the cached tables are kept on failure
+func (r *Registry) Refresh(ctx context.Context) error {
+	if r.source == nil {
+		return nil
+	}
+
+	var tables Tables
+	var err error
+	if tables.HDDSpecs, err = r.source.GetHDDSpecs(ctx); err != nil {
+		return fmt.Errorf("lookup:: failed to load hdd specs %v", err)
+	}
+	if tables.RAMSpecs, err = r.source.GetRAMSpecs(ctx); err != nil {
+		return fmt.Errorf("lookup:: failed to load ram specs %v", err)
+	}
+	if tables.Currencies, err = r.source.GetCurrencies(ctx); err != nil {
+		return fmt.Errorf("lookup:: failed to load currencies %v", err)
+	}
+	r.Set(tables)
+	return nil
+}
+
+// Watch refreshes the tables every interval until ctx is done, so changes made
+// through other instances are picked up
+func (r *Registry) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+// Set replaces the cached tables
+func (r *Registry) Set(tables Tables) {
+	hddIDs, hddNames := make(map[string]int), make(map[int]string)
+	for _, spec := range tables.HDDSpecs {
+		hddIDs[strings.ToUpper(spec.Type)] = int(spec.ID)
+		hddNames[int(spec.ID)] = spec.Type
+	}
+	ramIDs, ramNames := make(map[string]int), make(map[int]string)
+	for _, spec := range tables.RAMSpecs {
+		ramIDs[strings.ToUpper(spec.Type)] = int(spec.ID)
+		ramNames[int(spec.ID)] = spec.Type
+	}
+	codes, symbols, currencies := make(map[string]int), make(map[string]int), make(map[int]models.Currency)
+	for _, currency := range tables.Currencies {
+		codes[strings.ToUpper(currency.Code)] = int(currency.ID)
+		symbols[currency.Symbol] = int(currency.ID)
+		currencies[int(currency.ID)] = currency
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tables = tables
+	r.hddIDs, r.hddNames = hddIDs, hddNames
+	r.ramIDs, r.ramNames = ramIDs, ramNames
+	r.codes, r.symbols, r.currencies = codes, symbols, currencies
+}
+
+// Tables returns the cached tables
+func (r *Registry) Tables() Tables {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.tables
+}
+
+// HDDTypeID returns the ID of an HDD type such as SATA2 or SSD
+func (r *Registry) HDDTypeID(hddType string) (int, error) {
+	hddType = strings.ToUpper(hddType)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if id, ok := r.hddIDs[hddType]; ok {
+		return id, nil
+	}
+	return 0, fmt.Errorf("unknown HDD type: %s", hddType)
+}
+
+// HDDTypeName returns the name of an HDD type, empty when it is unknown
+func (r *Registry) HDDTypeName(id int) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.hddNames[id]
+}
+
+// RAMTypeID returns the ID of a RAM type such as DDR4
+func (r *Registry) RAMTypeID(ramType string) (int, error) {
+	ramType = strings.ToUpper(ramType)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if id, ok := r.ramIDs[ramType]; ok {
+		return id, nil
+	}
+	return 0, fmt.Errorf("unknown RAM type: %s", ramType)
+}
+
+// RAMTypeName returns the name of a RAM type, empty when it is unknown
+func (r *Registry) RAMTypeName(id int) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.ramNames[id]
+}
+
+// CurrencyIDBySymbol returns the ID of the currency written as symbol, e.g. €
+func (r *Registry) CurrencyIDBySymbol(symbol string) (int, error) {
+	symbol = strings.TrimSpace(symbol)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if id, ok := r.symbols[symbol]; ok {
+		return id, nil
+	}
+	return 0, fmt.Errorf("unknown currency symbol: %s", symbol)
+}
+
+// CurrencyIDByCode returns the ID of a currency by its ISO 4217 code
+func (r *Registry) CurrencyIDByCode(code string) (int, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if id, ok := r.codes[code]; ok {
+		return id, nil
+	}
+	return 0, fmt.Errorf("unknown currency code: %s", code)
+}
+
+// Currency returns the currency with the given ID
+func (r *Registry) Currency(id int) (models.Currency, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	currency, ok := r.currencies[id]
+	return currency, ok
+}
@@ -0,0 +1,315 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"testing"
+)
+
+func TestRegistry_HDDTypeID(t *testing.T) {
+	registry := Static(Seed)
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    int
+		expectError bool
+	}{
+		{
+			name:        "valid SATA2",
+			input:       "SATA2",
+			expected:    utils.HDDTypeSATA2,
+			expectError: false,
+		},
+		{
+			name:        "valid SAS",
+			input:       "SAS",
+			expected:    utils.HDDTypeSAS,
+			expectError: false,
+		},
+		{
+			name:        "valid SSD",
+			input:       "SSD",
+			expected:    utils.HDDTypeSSD,
+			expectError: false,
+		},
+		{
+			name:        "case insensitive SATA2",
+			input:       "sata2",
+			expected:    utils.HDDTypeSATA2,
+			expectError: false,
+		},
+		{
+			name:        "invalid type",
+			input:       "INVALID",
+			expected:    0,
+			expectError: true,
+		},
+		{
+			name:        "empty string",
+			input:       "",
+			expected:    0,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registry.HDDTypeID(tt.input)
+			if (err != nil) != tt.expectError {
+				t.Errorf("HDDTypeID() error = %v, expectError %v", err, tt.expectError)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("HDDTypeID() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegistry_RAMTypeID(t *testing.T) {
+	registry := Static(Seed)
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    int
+		expectError bool
+	}{
+		{
+			name:        "valid DDR3",
+			input:       "DDR3",
+			expected:    utils.RAMTypeDDR3,
+			expectError: false,
+		},
+		{
+			name:        "valid DDR4",
+			input:       "DDR4",
+			expected:    utils.RAMTypeDDR4,
+			expectError: false,
+		},
+		{
+			name:        "valid DDR5",
+			input:       "DDR5",
+			expected:    utils.RAMTypeDDR5,
+			expectError: false,
+		},
+		{
+			name:        "case insensitive DDR3",
+			input:       "ddr3",
+			expected:    utils.RAMTypeDDR3,
+			expectError: false,
+		},
+		{
+			name:        "invalid type",
+			input:       "INVALID",
+			expected:    0,
+			expectError: true,
+		},
+		{
+			name:        "empty string",
+			input:       "",
+			expected:    0,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registry.RAMTypeID(tt.input)
+			if (err != nil) != tt.expectError {
+				t.Errorf("RAMTypeID() error = %v, expectError %v", err, tt.expectError)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("RAMTypeID() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegistry_CurrencyIDBySymbol(t *testing.T) {
+	registry := Static(Seed)
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    int
+		expectError bool
+	}{
+		{
+			name:        "valid USD",
+			input:       utils.CurrencySymbolUSD,
+			expected:    utils.CurrencyUSD,
+			expectError: false,
+		},
+		{
+			name:        "valid Euro",
+			input:       utils.CurrencySymbolEuro,
+			expected:    utils.CurrencyEuro,
+			expectError: false,
+		},
+		{
+			name:        "valid SGD",
+			input:       utils.CurrencySymbolSGD,
+			expected:    utils.CurrencySGD,
+			expectError: false,
+		},
+		{
+			name:        "with spaces",
+			input:       " " + utils.CurrencySymbolUSD + " ",
+			expected:    utils.CurrencyUSD,
+			expectError: false,
+		},
+		{
+			name:        "invalid symbol",
+			input:       "¥",
+			expected:    0,
+			expectError: true,
+		},
+		{
+			name:        "empty string",
+			input:       "",
+			expected:    0,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registry.CurrencyIDBySymbol(tt.input)
+			if (err != nil) != tt.expectError {
+				t.Errorf("CurrencyIDBySymbol() error = %v, expectError %v", err, tt.expectError)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("CurrencyIDBySymbol() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegistry_CurrencyIDByCode(t *testing.T) {
+	registry := Static(Seed)
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    int
+		expectError bool
+	}{
+		{
+			name:        "valid USD",
+			input:       utils.CurrencyCodeUSD,
+			expected:    utils.CurrencyUSD,
+			expectError: false,
+		},
+		{
+			name:        "valid Euro",
+			input:       utils.CurrencyCodeEuro,
+			expected:    utils.CurrencyEuro,
+			expectError: false,
+		},
+		{
+			name:        "case insensitive SGD",
+			input:       " sgd ",
+			expected:    utils.CurrencySGD,
+			expectError: false,
+		},
+		{
+			name:        "symbol is not a code",
+			input:       utils.CurrencySymbolUSD,
+			expected:    0,
+			expectError: true,
+		},
+		{
+			name:        "unknown code",
+			input:       "JPY",
+			expected:    0,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registry.CurrencyIDByCode(tt.input)
+			if (err != nil) != tt.expectError {
+				t.Errorf("CurrencyIDByCode() error = %v, expectError %v", err, tt.expectError)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("CurrencyIDByCode() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+type fakeSource struct {
+	tables Tables
+	err    error
+}
+
+func (f *fakeSource) GetHDDSpecs(ctx context.Context) ([]models.HDDSpec, error) {
+	return f.tables.HDDSpecs, f.err
+}
+
+func (f *fakeSource) GetRAMSpecs(ctx context.Context) ([]models.RamSpec, error) {
+	return f.tables.RAMSpecs, f.err
+}
+
+func (f *fakeSource) GetCurrencies(ctx context.Context) ([]models.Currency, error) {
+	return f.tables.Currencies, f.err
+}
+
+func TestRegistry_Refresh(t *testing.T) {
+	source := &fakeSource{tables: Seed}
+	registry := New(source)
+	if _, err := registry.HDDTypeID("SSD"); err == nil {
+		t.Fatalf("HDDTypeID() of an unloaded registry should fail")
+	}
+
+	if err := registry.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if _, err := registry.HDDTypeID("NVMe"); err == nil {
+		t.Errorf("HDDTypeID() of a type added later should fail before the next refresh")
+	}
+
+	// a type added to the table is known after the next refresh
+	source.tables.HDDSpecs = append(append([]models.HDDSpec{}, Seed.HDDSpecs...), models.HDDSpec{ID: 4, Type: "NVMe"})
+	if err := registry.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if id, err := registry.HDDTypeID("nvme"); err != nil || id != 4 {
+		t.Errorf("HDDTypeID() = %v, %v, want 4", id, err)
+	}
+	if name := registry.HDDTypeName(4); name != "NVMe" {
+		t.Errorf("HDDTypeName() = %v, want NVMe", name)
+	}
+
+	// the cached tables are kept when the source fails
+	source.err = errors.New("connection refused")
+	if err := registry.Refresh(context.Background()); err == nil {
+		t.Errorf("Refresh() should fail with the source")
+	}
+	if name := registry.HDDTypeName(4); name != "NVMe" {
+		t.Errorf("HDDTypeName() after a failed refresh = %v, want NVMe", name)
+	}
+}
+
+func TestRegistry_Currency(t *testing.T) {
+	registry := Static(Seed)
+
+	currency, ok := registry.Currency(utils.CurrencySGD)
+	if !ok || currency.Symbol != "S$" || currency.Code != "SGD" {
+		t.Errorf("Currency() = %+v, %v, want the Singapore Dollar", currency, ok)
+	}
+	if _, ok := registry.Currency(42); ok {
+		t.Errorf("Currency() of an unknown ID should not be found")
+	}
+	if name := registry.RAMTypeName(utils.RAMTypeDDR5); name != "DDR5" {
+		t.Errorf("RAMTypeName() = %v, want DDR5", name)
+	}
+}
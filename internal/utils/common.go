@@ -2,26 +2,26 @@ package utils
 
 import (
 	"encoding/json"
-	"fmt"
 	_ "github.com/davecgh/go-spew/spew"
 	"io"
 	"strings"
 )
 
-// HDD Types
+// HDD Types seeded by the migrations, the lookup registry resolves every type of the hdd_spec table
 const (
 	HDDTypeSATA2 = 1
 	HDDTypeSAS   = 2
 	HDDTypeSSD   = 3
 )
 
-// RAM Types
+// RAM Types seeded by the migrations
 const (
 	RAMTypeDDR3 = 1
 	RAMTypeDDR4 = 2
+	RAMTypeDDR5 = 3
 )
 
-// Currency Types
+// Currency Types seeded by the migrations
 const (
 	CurrencyUSD  = 1
 	CurrencyEuro = 2
@@ -35,6 +35,13 @@ const (
 	CurrencySymbolSGD  = "S$"
 )
 
+// Currency Codes (ISO 4217)
+const (
+	CurrencyCodeUSD  = "USD"
+	CurrencyCodeEuro = "EUR"
+	CurrencyCodeSGD  = "SGD"
+)
+
 const (
 	StorageUnitGB = 1
 	StorageUnitTB = 1024 // 1 TB = 1024 GB
@@ -43,34 +50,6 @@ const (
 	HDDUnitTB = "TB"
 )
 
-// GetHDDTypeID returns the HDD type ID based on the parsed type string.
-func GetHDDTypeID(hddType string) (int, error) {
-	hddType = strings.ToUpper(hddType)
-	switch hddType {
-	case "SATA2":
-		return HDDTypeSATA2, nil
-	case "SAS":
-		return HDDTypeSAS, nil
-	case "SSD":
-		return HDDTypeSSD, nil
-	default:
-		return 0, fmt.Errorf("unknown HDD type: %s", hddType)
-	}
-}
-
-// GetRAMTypeID returns the RAM type ID based on the parsed type string.
-func GetRAMTypeID(ramType string) (int, error) {
-	ramType = strings.ToUpper(ramType)
-	switch ramType {
-	case "DDR3":
-		return RAMTypeDDR3, nil
-	case "DDR4":
-		return RAMTypeDDR4, nil
-	default:
-		return 0, fmt.Errorf("unknown RAM type: %s", ramType)
-	}
-}
-
 // ConvertToGB return the size in Gigabytes
 func ConvertToGB(size int, unit string) int {
 	switch strings.ToUpper(unit) {
@@ -83,22 +62,6 @@ func ConvertToGB(size int, unit string) int {
 	}
 }
 
-// GetCurrencyID returns the currency ID based on the parsed currency symbol.
-func GetCurrencyID(currencySymbol string) (int, error) {
-	currencySymbol = strings.TrimSpace(currencySymbol)
-
-	switch currencySymbol {
-	case CurrencySymbolUSD:
-		return CurrencyUSD, nil
-	case CurrencySymbolEuro:
-		return CurrencyEuro, nil
-	case CurrencySymbolSGD:
-		return CurrencySGD, nil
-	default:
-		return 0, fmt.Errorf("unknown currency symbol: %s", currencySymbol)
-	}
-}
-
 // ParseJSON parses the JSON response body into the provided interface
 func ParseJSON(body io.Reader, v interface{}) error {
 	return json.NewDecoder(body).Decode(v)
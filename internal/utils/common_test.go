@@ -5,118 +5,6 @@ import (
 	"testing"
 )
 
-func TestGetHDDTypeID(t *testing.T) {
-	tests := []struct {
-		name        string
-		input       string
-		expected    int
-		expectError bool
-	}{
-		{
-			name:        "valid SATA2",
-			input:       "SATA2",
-			expected:    HDDTypeSATA2,
-			expectError: false,
-		},
-		{
-			name:        "valid SAS",
-			input:       "SAS",
-			expected:    HDDTypeSAS,
-			expectError: false,
-		},
-		{
-			name:        "valid SSD",
-			input:       "SSD",
-			expected:    HDDTypeSSD,
-			expectError: false,
-		},
-		{
-			name:        "case insensitive SATA2",
-			input:       "sata2",
-			expected:    HDDTypeSATA2,
-			expectError: false,
-		},
-		{
-			name:        "invalid type",
-			input:       "INVALID",
-			expected:    0,
-			expectError: true,
-		},
-		{
-			name:        "empty string",
-			input:       "",
-			expected:    0,
-			expectError: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := GetHDDTypeID(tt.input)
-			if (err != nil) != tt.expectError {
-				t.Errorf("GetHDDTypeID() error = %v, expectError %v", err, tt.expectError)
-				return
-			}
-			if got != tt.expected {
-				t.Errorf("GetHDDTypeID() = %v, want %v", got, tt.expected)
-			}
-		})
-	}
-}
-
-func TestGetRAMTypeID(t *testing.T) {
-	tests := []struct {
-		name        string
-		input       string
-		expected    int
-		expectError bool
-	}{
-		{
-			name:        "valid DDR3",
-			input:       "DDR3",
-			expected:    RAMTypeDDR3,
-			expectError: false,
-		},
-		{
-			name:        "valid DDR4",
-			input:       "DDR4",
-			expected:    RAMTypeDDR4,
-			expectError: false,
-		},
-		{
-			name:        "case insensitive DDR3",
-			input:       "ddr3",
-			expected:    RAMTypeDDR3,
-			expectError: false,
-		},
-		{
-			name:        "invalid type",
-			input:       "INVALID",
-			expected:    0,
-			expectError: true,
-		},
-		{
-			name:        "empty string",
-			input:       "",
-			expected:    0,
-			expectError: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := GetRAMTypeID(tt.input)
-			if (err != nil) != tt.expectError {
-				t.Errorf("GetRAMTypeID() error = %v, expectError %v", err, tt.expectError)
-				return
-			}
-			if got != tt.expected {
-				t.Errorf("GetRAMTypeID() = %v, want %v", got, tt.expected)
-			}
-		})
-	}
-}
-
 func TestConvertToGB(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -166,65 +54,6 @@ func TestConvertToGB(t *testing.T) {
 	}
 }
 
-func TestGetCurrencyID(t *testing.T) {
-	tests := []struct {
-		name        string
-		input       string
-		expected    int
-		expectError bool
-	}{
-		{
-			name:        "valid USD",
-			input:       CurrencySymbolUSD,
-			expected:    CurrencyUSD,
-			expectError: false,
-		},
-		{
-			name:        "valid Euro",
-			input:       CurrencySymbolEuro,
-			expected:    CurrencyEuro,
-			expectError: false,
-		},
-		{
-			name:        "valid SGD",
-			input:       CurrencySymbolSGD,
-			expected:    CurrencySGD,
-			expectError: false,
-		},
-		{
-			name:        "with spaces",
-			input:       " " + CurrencySymbolUSD + " ",
-			expected:    CurrencyUSD,
-			expectError: false,
-		},
-		{
-			name:        "invalid symbol",
-			input:       "¥",
-			expected:    0,
-			expectError: true,
-		},
-		{
-			name:        "empty string",
-			input:       "",
-			expected:    0,
-			expectError: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := GetCurrencyID(tt.input)
-			if (err != nil) != tt.expectError {
-				t.Errorf("GetCurrencyID() error = %v, expectError %v", err, tt.expectError)
-				return
-			}
-			if got != tt.expected {
-				t.Errorf("GetCurrencyID() = %v, want %v", got, tt.expected)
-			}
-		})
-	}
-}
-
 func TestParseJSON(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1,12 +1,71 @@
 package utils
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Errors maps a string key to a list of values.
 type Errors map[string][]string
 
+// Add appends value to the list of values stored under key.
+func (e Errors) Add(key, value string) {
+	e[key] = append(e[key], value)
+}
+
 // Different error types
 var (
-	ErrServerNotFound = errors.New("server not found")
-	ErrUploadFailed   = errors.New("failed to upload data into the database")
+	ErrServerNotFound  = errors.New("server not found")
+	ErrUploadFailed    = errors.New("failed to upload data into the database")
+	ErrJobNotFound     = errors.New("upload job not found")
+	ErrJobQueueFull    = errors.New("upload job queue is full")
+	ErrVersionNotFound = errors.New("catalog version not found")
+	ErrInvalidLookup   = errors.New("invalid lookup value")
+	ErrLookupExists    = errors.New("lookup value already exists")
+	ErrUploadNotFound  = errors.New("upload not found")
+	ErrDuplicateUpload = errors.New("file matches the last successful upload")
 )
+
+// RowError describes a single invalid cell found while validating an uploaded catalog
+type RowError struct {
+	Sheet  string `json:"sheet,omitempty"`
+	Row    int    `json:"row"`
+	Column string `json:"column"`
+	Cell   string `json:"cell"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// String renders the error as a single human readable line
+func (re RowError) String() string {
+	if re.Sheet != "" {
+		return fmt.Sprintf("sheet %s, row %d, column %s, value %q: %s", re.Sheet, re.Row, re.Column, re.Value, re.Reason)
+	}
+	return fmt.Sprintf("row %d, column %s, value %q: %s", re.Row, re.Column, re.Value, re.Reason)
+}
+
+// Ref returns the cell reference of the error, qualified with the sheet name when it is set
+func (re RowError) Ref() string {
+	if re.Sheet != "" {
+		return re.Sheet + "!" + re.Cell
+	}
+	return re.Cell
+}
+
+// ValidationError is returned when one or more rows of an uploaded catalog are invalid
+type ValidationError struct {
+	Rows []RowError
+}
+
+func (ve *ValidationError) Error() string {
+	return fmt.Sprintf("invalid catalog: %d invalid cell(s)", len(ve.Rows))
+}
+
+// Errors returns the report keyed by cell reference
+func (ve *ValidationError) Errors() Errors {
+	errs := Errors{}
+	for _, re := range ve.Rows {
+		errs.Add(re.Ref(), re.String())
+	}
+	return errs
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidationError_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     []RowError
+		expected Errors
+	}{
+		{
+			name:     "no rows",
+			rows:     nil,
+			expected: Errors{},
+		},
+		{
+			name: "one error per cell",
+			rows: []RowError{
+				{Row: 17, Column: "RAM", Cell: "B17", Value: "16GB", Reason: "invalid RAM format"},
+				{Row: 17, Column: "HDD", Cell: "C17", Value: "2x1TB", Reason: "invalid HDD format"},
+			},
+			expected: Errors{
+				"B17": {`row 17, column RAM, value "16GB": invalid RAM format`},
+				"C17": {`row 17, column HDD, value "2x1TB": invalid HDD format`},
+			},
+		},
+		{
+			name: "several errors on the same cell",
+			rows: []RowError{
+				{Row: 2, Column: "Price", Cell: "E2", Value: "", Reason: "price is required"},
+				{Row: 2, Column: "Price", Cell: "E2", Value: "", Reason: "unknown currency symbol: "},
+			},
+			expected: Errors{
+				"E2": {
+					`row 2, column Price, value "": price is required`,
+					`row 2, column Price, value "": unknown currency symbol: `,
+				},
+			},
+		},
+		{
+			name: "same cell on different sheets",
+			rows: []RowError{
+				{Sheet: "EU", Row: 3, Column: "RAM", Cell: "B3", Value: "16GB", Reason: "invalid RAM format"},
+				{Sheet: "US", Row: 3, Column: "RAM", Cell: "B3", Value: "8GB", Reason: "invalid RAM format"},
+			},
+			expected: Errors{
+				"EU!B3": {`sheet EU, row 3, column RAM, value "16GB": invalid RAM format`},
+				"US!B3": {`sheet US, row 3, column RAM, value "8GB": invalid RAM format`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ve := &ValidationError{Rows: tt.rows}
+			if got := ve.Errors(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ValidationError.Errors() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -6,13 +6,6 @@ import (
 	"strings"
 )
 
-const (
-	// Database values
-	HDDSATA2DB = "SATA2"
-	HDDSASDB   = "SAS"
-	HDDSSDDB   = "SSD"
-)
-
 func ParseStorageToGB(storage string) (int, error) {
 	storage = strings.TrimSpace(storage)
 	if storage == "0" {
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParsePriceRange parses the bounds of a price range, an empty bound is left nil.
+// Bounds are non-negative amounts and min can't exceed max.
+func ParsePriceRange(min, max string) (*float64, *float64, error) {
+	parse := func(name, value string) (*float64, error) {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, nil
+		}
+		price, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(price) || math.IsInf(price, 0) {
+			return nil, fmt.Errorf("invalid %s: %s", name, value)
+		}
+		if price < 0 {
+			return nil, fmt.Errorf("%s can't be negative: %s", name, value)
+		}
+		return &price, nil
+	}
+
+	minPrice, err := parse("min_price", min)
+	if err != nil {
+		return nil, nil, err
+	}
+	maxPrice, err := parse("max_price", max)
+	if err != nil {
+		return nil, nil, err
+	}
+	if minPrice != nil && maxPrice != nil && *minPrice > *maxPrice {
+		return nil, nil, fmt.Errorf("min_price %s exceeds max_price %s", min, max)
+	}
+	return minPrice, maxPrice, nil
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestParsePriceRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		min     string
+		max     string
+		wantMin *float64
+		wantMax *float64
+		wantErr bool
+	}{
+		{
+			name: "no bounds",
+		},
+		{
+			name:    "both bounds",
+			min:     "49.99",
+			max:     "100",
+			wantMin: &[]float64{49.99}[0],
+			wantMax: &[]float64{100}[0],
+		},
+		{
+			name:    "upper bound only",
+			max:     "100",
+			wantMax: &[]float64{100}[0],
+		},
+		{
+			name:    "equal bounds",
+			min:     "50",
+			max:     "50",
+			wantMin: &[]float64{50}[0],
+			wantMax: &[]float64{50}[0],
+		},
+		{
+			name:    "not a number",
+			max:     "€100",
+			wantErr: true,
+		},
+		{
+			name:    "infinite bound",
+			min:     "Inf",
+			wantErr: true,
+		},
+		{
+			name:    "negative bound",
+			min:     "-1",
+			wantErr: true,
+		},
+		{
+			name:    "min exceeds max",
+			min:     "100",
+			max:     "50",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, max, err := ParsePriceRange(tt.min, tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePriceRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !equalPrice(min, tt.wantMin) || !equalPrice(max, tt.wantMax) {
+				t.Errorf("ParsePriceRange() = %v, %v, want %v, %v", min, max, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func equalPrice(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
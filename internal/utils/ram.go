@@ -10,10 +10,14 @@ func ParseRAMValues(ramStr string) []int {
 	result := make([]int, 0, len(ramValues))
 
 	for _, v := range ramValues {
-		v = strings.TrimSpace(v)
-		v = strings.TrimSuffix(v, "GB")
+		v = strings.ToUpper(strings.TrimSpace(v))
+		unit := StorageUnitGB
+		if strings.HasSuffix(v, HDDUnitTB) {
+			unit = StorageUnitTB
+		}
+		v = strings.TrimSuffix(strings.TrimSuffix(v, HDDUnitGB), HDDUnitTB)
 		if num, err := strconv.Atoi(v); err == nil {
-			result = append(result, num)
+			result = append(result, num*unit)
 		}
 	}
 
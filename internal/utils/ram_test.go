@@ -26,6 +26,11 @@ func TestParseRAMValues(t *testing.T) {
 			input:    "16GB, 32GB, 64GB",
 			expected: []int{16, 32, 64},
 		},
+		{
+			name:     "terabyte values",
+			input:    "512GB,1TB,2tb",
+			expected: []int{512, 1024, 2048},
+		},
 		{
 			name:     "empty string",
 			input:    "",
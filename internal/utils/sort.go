@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sort keys of the server list
+const (
+	SortPrice    = "price"
+	SortRAM      = "ram"
+	SortStorage  = "storage"
+	SortModel    = "model"
+	SortLocation = "location"
+)
+
+// ServerSortKeys lists the keys the server list can be sorted by
+var ServerSortKeys = []string{SortPrice, SortRAM, SortStorage, SortModel, SortLocation}
+
+// SortKey orders a list by Key, descending when Desc is set
+type SortKey struct {
+	Key  string
+	Desc bool
+}
+
+// ParseSort parses comma separated sort keys such as price,-ram where a leading -
+// sorts descending. Keys missing from allowed and repeated keys are rejected.
+func ParseSort(sort string, allowed []string) ([]SortKey, error) {
+	var keys []SortKey
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(sort, ",") {
+		part = strings.TrimSpace(part)
+		key := SortKey{Key: strings.ToLower(strings.TrimPrefix(part, "-")), Desc: strings.HasPrefix(part, "-")}
+
+		known := false
+		for _, k := range allowed {
+			known = known || k == key.Key
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown sort key %q, use %s", part, strings.Join(allowed, ", "))
+		}
+		if seen[key.Key] {
+			return nil, fmt.Errorf("sort key %q is repeated", key.Key)
+		}
+		seen[key.Key] = true
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     string
+		expected []SortKey
+		wantErr  bool
+	}{
+		{
+			name:     "single key",
+			sort:     "price",
+			expected: []SortKey{{Key: SortPrice}},
+		},
+		{
+			name:     "descending and ascending keys",
+			sort:     "-ram, price",
+			expected: []SortKey{{Key: SortRAM, Desc: true}, {Key: SortPrice}},
+		},
+		{
+			name:     "keys are case insensitive",
+			sort:     "-Storage",
+			expected: []SortKey{{Key: SortStorage, Desc: true}},
+		},
+		{
+			name:    "unknown key",
+			sort:    "price,cpu",
+			wantErr: true,
+		},
+		{
+			name:    "empty key",
+			sort:    "price,",
+			wantErr: true,
+		},
+		{
+			name:    "repeated key",
+			sort:    "price,-price",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := ParseSort(tt.sort, ServerSortKeys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSort() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(keys, tt.expected) {
+				t.Errorf("ParseSort() = %v, want %v", keys, tt.expected)
+			}
+		})
+	}
+}
@@ -1,11 +1,19 @@
 package middleware
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"github.com/server-catalog/internal/config"
 	"github.com/server-catalog/internal/utils"
 	"net/http"
 )
 
+type ctxKey int
+
+// keyIdentityKey holds the identity of the App-key that authenticated a request
+const keyIdentityKey ctxKey = iota
+
 func AppKeyResolver(next http.Handler) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
 
@@ -28,7 +36,20 @@ func AppKeyResolver(next http.Handler) http.Handler {
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		ctx := context.WithValue(r.Context(), keyIdentityKey, keyIdentity(appKey))
+		next.ServeHTTP(w, r.WithContext(ctx))
 	}
 	return http.HandlerFunc(fn)
 }
+
+// keyIdentity names an App-key by a fingerprint so the key itself is never stored
+func keyIdentity(appKey string) string {
+	sum := sha256.Sum256([]byte(appKey))
+	return "app-key:" + hex.EncodeToString(sum[:6])
+}
+
+// KeyIdentity returns the identity of the App-key that authenticated the request, empty when there is none
+func KeyIdentity(ctx context.Context) string {
+	identity, _ := ctx.Value(keyIdentityKey).(string)
+	return identity
+}
@@ -1,20 +1,64 @@
 package models
 
+import (
+	"fmt"
+	"strings"
+)
+
 // ServerCatalog represents a server in the catalog
 // @Description Server catalog information
 type ServerCatalog struct {
-	ID       uint    `json:"-" gorm:"primaryKey;autoIncrement;column:id" swaggerignore:"true"`
-	Model    string  `json:"model" gorm:"type:varchar(128);not null;column:model" example:"HP DL120G7Intel G850"`
-	RamSize  int     `json:"ram_size" gorm:"not null;column:ram_size" example:"4"`
-	RamType  int     `json:"ram_type" gorm:"not null;column:ram_type;foreignKey:RamType;references:ID" example:"1"`
-	HDDSize  int     `json:"hdd_size" gorm:"not null;column:hdd_size" example:"1000"`
-	HDDCount int     `json:"hdd_count" gorm:"not null;column:hdd_count" example:"4"`
-	HDDType  int     `json:"hdd_type" gorm:"not null;column:hdd_type;foreignKey:HDDType;references:ID" example:"2"`
-	Location string  `json:"location" gorm:"type:varchar(128);not null;column:location" example:"AmsterdamAMS-01"`
-	Price    float64 `json:"price" gorm:"type:decimal(20,2);unsigned;not null;column:price" example:"39.99"`
-	Currency int     `json:"currency" gorm:"not null;column:currency;foreignKey:Currency;references:ID" example:"1"`
+	ID        uint         `json:"-" gorm:"primaryKey;autoIncrement;column:id" swaggerignore:"true"`
+	Model     string       `json:"model" gorm:"type:varchar(128);not null;column:model" example:"HP DL120G7Intel G850"`
+	RamSize   int          `json:"ram_size" gorm:"not null;column:ram_size" example:"4"`
+	RamType   int          `json:"ram_type" gorm:"not null;column:ram_type;foreignKey:RamType;references:ID" example:"1"`
+	RamECC    bool         `json:"ram_ecc" gorm:"not null;default:false;column:ram_ecc" example:"true"`
+	RamSpeed  int          `json:"ram_speed" gorm:"not null;default:0;column:ram_speed" example:"2666"` // MT/s, 0 when unknown
+	Disks     []ServerDisk `json:"disks" gorm:"foreignKey:ServerID;constraint:OnDelete:CASCADE"`
+	Location  string       `json:"location" gorm:"type:varchar(128);not null;column:location" example:"AmsterdamAMS-01"`
+	Price     float64      `json:"price" gorm:"type:decimal(20,2);unsigned;not null;column:price" example:"39.99"`
+	Currency  int          `json:"currency" gorm:"not null;column:currency;foreignKey:Currency;references:ID" example:"1"`
+	VersionID uint         `json:"-" gorm:"not null;index;column:version_id" swaggerignore:"true"` // version that inserted the server, see CatalogVersionServer
+	ModelID   uint         `json:"-" gorm:"not null;index;column:model_id" swaggerignore:"true"`
+	// ServerModel holds the parts of the model, it is only loaded by the read queries
+	ServerModel *ServerModel `json:"-" gorm:"foreignKey:ModelID" swaggerignore:"true"`
+	LocationID  uint         `json:"-" gorm:"not null;index;column:location_id" swaggerignore:"true"`
+	// DataCenter holds the parts of the location, it is only loaded by the read queries
+	DataCenter *Location `json:"-" gorm:"foreignKey:LocationID" swaggerignore:"true"`
 }
 
 func (sc *ServerCatalog) TableName() string {
 	return "server_catalog"
 }
+
+// Storage returns the capacity of every disk of the server in GB
+func (sc *ServerCatalog) Storage() int {
+	var storage int
+	for _, disk := range sc.Disks {
+		storage += disk.HDDCount * disk.HDDSize
+	}
+	return storage
+}
+
+// NaturalKey identifies a server configuration regardless of its price
+func (sc *ServerCatalog) NaturalKey() string {
+	disks := make([]string, 0, len(sc.Disks))
+	for _, disk := range sc.Disks {
+		disks = append(disks, fmt.Sprintf("%dx%d/%d", disk.HDDCount, disk.HDDSize, disk.HDDType))
+	}
+	return fmt.Sprintf("%s|%d|%d|%t|%d|%s|%s", sc.Model, sc.RamSize, sc.RamType, sc.RamECC, sc.RamSpeed,
+		strings.Join(disks, "+"), sc.Location)
+}
+
+// ServerDisk is a group of identical disks of a server, e.g. the 2x120GBSSD of 2x120GBSSD+4x2TBSATA2
+type ServerDisk struct {
+	ID       uint `json:"-" gorm:"primaryKey;autoIncrement;column:id" swaggerignore:"true"`
+	ServerID uint `json:"-" gorm:"not null;index;column:server_id" swaggerignore:"true"`
+	HDDCount int  `json:"hdd_count" gorm:"not null;column:hdd_count" example:"4"`
+	HDDSize  int  `json:"hdd_size" gorm:"not null;column:hdd_size" example:"1000"`
+	HDDType  int  `json:"hdd_type" gorm:"not null;column:hdd_type;foreignKey:HDDType;references:ID" example:"2"`
+}
+
+func (sd *ServerDisk) TableName() string {
+	return "server_disk"
+}
@@ -21,9 +21,7 @@ func TestServerCatalog_TableName(t *testing.T) {
 				Model:    "HP DL120G7Intel G850",
 				RamSize:  4,
 				RamType:  1,
-				HDDSize:  1000,
-				HDDCount: 4,
-				HDDType:  2,
+				Disks:    []ServerDisk{{HDDCount: 4, HDDSize: 1000, HDDType: 2}},
 				Location: "AmsterdamAMS-01",
 				Price:    39.99,
 				Currency: 1,
@@ -40,3 +38,43 @@ func TestServerCatalog_TableName(t *testing.T) {
 		})
 	}
 }
+
+func TestServerCatalog_Storage(t *testing.T) {
+	catalog := ServerCatalog{
+		Disks: []ServerDisk{
+			{HDDCount: 2, HDDSize: 120, HDDType: 3},
+			{HDDCount: 4, HDDSize: 2048, HDDType: 1},
+		},
+	}
+	if got := catalog.Storage(); got != 8432 {
+		t.Errorf("ServerCatalog.Storage() = %v, want %v", got, 8432)
+	}
+}
+
+func TestServerCatalog_NaturalKey(t *testing.T) {
+	single := ServerCatalog{Model: "Dell R210-II", RamSize: 16, RamType: 1, Location: "AmsterdamAMS-01",
+		Disks: []ServerDisk{{HDDCount: 2, HDDSize: 120, HDDType: 3}}}
+	mixed := ServerCatalog{Model: "Dell R210-II", RamSize: 16, RamType: 1, Location: "AmsterdamAMS-01",
+		Disks: []ServerDisk{{HDDCount: 2, HDDSize: 120, HDDType: 3}, {HDDCount: 4, HDDSize: 2048, HDDType: 1}}}
+
+	expected := "Dell R210-II|16|1|false|0|2x120/3+4x2048/1|AmsterdamAMS-01"
+	if got := mixed.NaturalKey(); got != expected {
+		t.Errorf("ServerCatalog.NaturalKey() = %v, want %v", got, expected)
+	}
+	if single.NaturalKey() == mixed.NaturalKey() {
+		t.Errorf("ServerCatalog.NaturalKey() does not tell the disk groups apart")
+	}
+
+	ecc := mixed
+	ecc.RamECC = true
+	if ecc.NaturalKey() == mixed.NaturalKey() {
+		t.Errorf("ServerCatalog.NaturalKey() does not tell ECC memory apart")
+	}
+}
+
+func TestServerDisk_TableName(t *testing.T) {
+	disk := ServerDisk{}
+	if got := disk.TableName(); got != "server_disk" {
+		t.Errorf("ServerDisk.TableName() = %v, want %v", got, "server_disk")
+	}
+}
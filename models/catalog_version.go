@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// CatalogVersion is an immutable snapshot of the catalog created by an upload.
+// Exactly one version is active and served by the list endpoints.
+type CatalogVersion struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement;column:id"`
+	Mode        string     `gorm:"type:varchar(16);not null;column:mode"`
+	ServerCount int        `gorm:"not null;column:server_count"`
+	Active      bool       `gorm:"not null;default:false;column:active"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	ActivatedAt *time.Time `gorm:"column:activated_at"`
+}
+
+func (cv *CatalogVersion) TableName() string {
+	return "catalog_version"
+}
+
+// CatalogVersionServer adds a server to a catalog version. Servers are shared by
+// every version they belong to, a version only stores the servers it inserted or
+// repriced next to the membership of the servers it took over.
+type CatalogVersionServer struct {
+	VersionID uint `gorm:"primaryKey;autoIncrement:false;column:version_id"`
+	ServerID  uint `gorm:"primaryKey;autoIncrement:false;index;column:server_id"`
+}
+
+func (cvs *CatalogVersionServer) TableName() string {
+	return "catalog_version_server"
+}
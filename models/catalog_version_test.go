@@ -0,0 +1,19 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestCatalogVersion_TableName(t *testing.T) {
+	version := CatalogVersion{}
+	if got := version.TableName(); got != "catalog_version" {
+		t.Errorf("CatalogVersion.TableName() = %v, want %v", got, "catalog_version")
+	}
+}
+
+func TestCatalogVersionServer_TableName(t *testing.T) {
+	member := CatalogVersionServer{}
+	if got := member.TableName(); got != "catalog_version_server" {
+		t.Errorf("CatalogVersionServer.TableName() = %v, want %v", got, "catalog_version_server")
+	}
+}
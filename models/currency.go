@@ -1,7 +1,63 @@
 package models
 
+import (
+	"strconv"
+	"strings"
+)
+
+// Symbol positions of a currency
+const (
+	SymbolBefore = "before" // $1,299.00
+	SymbolAfter  = "after"  // 1.299,00 €, separated by a space
+)
+
+// Currency is a currency prices of the catalog are given in, together with the
+// rules its prices are rendered with
 type Currency struct {
-	ID     uint
-	Type   string
-	Symbol string
+	ID     uint   `gorm:"primaryKey;autoIncrement;column:id"`
+	Code   string `gorm:"type:char(3);not null;uniqueIndex;column:code"` // ISO 4217
+	Type   string `gorm:"type:varchar(16);not null;column:type"`         // name of the currency
+	Symbol string `gorm:"type:varchar(8);not null;uniqueIndex;column:symbol"`
+
+	SymbolPosition     string `gorm:"type:varchar(8);not null;column:symbol_position"`
+	Decimals           int    `gorm:"not null;column:decimals"`
+	DecimalSeparator   string `gorm:"type:varchar(4);not null;column:decimal_separator"`
+	ThousandsSeparator string `gorm:"type:varchar(4);not null;column:thousands_separator"` // empty to not group the digits
+}
+
+func (c *Currency) TableName() string {
+	return "currency"
+}
+
+// Format renders an amount with the symbol, decimal places and separators of the currency
+func (c *Currency) Format(amount float64) string {
+	number := strconv.FormatFloat(amount, 'f', c.Decimals, 64)
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+	integer, fraction, _ := strings.Cut(number, ".")
+
+	if c.ThousandsSeparator != "" {
+		groups := make([]string, 0, len(integer)/3+1)
+		for len(integer) > 3 {
+			groups = append([]string{integer[len(integer)-3:]}, groups...)
+			integer = integer[:len(integer)-3]
+		}
+		integer = strings.Join(append([]string{integer}, groups...), c.ThousandsSeparator)
+	}
+
+	number = sign + integer
+	if fraction != "" {
+		decimalSeparator := c.DecimalSeparator
+		if decimalSeparator == "" {
+			decimalSeparator = "."
+		}
+		number += decimalSeparator + fraction
+	}
+
+	if c.SymbolPosition == SymbolAfter {
+		return number + " " + c.Symbol
+	}
+	return c.Symbol + number
 }
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestCurrency_TableName(t *testing.T) {
+	currency := Currency{}
+	if got := currency.TableName(); got != "currency" {
+		t.Errorf("Currency.TableName() = %v, want %v", got, "currency")
+	}
+}
+
+func TestCurrency_Format(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency Currency
+		amount   float64
+		expected string
+	}{
+		{
+			name:     "symbol before without grouping",
+			currency: Currency{Symbol: "$", SymbolPosition: SymbolBefore, Decimals: 2, DecimalSeparator: "."},
+			amount:   1299,
+			expected: "$1299.00",
+		},
+		{
+			name:     "grouped thousands",
+			currency: Currency{Symbol: "£", SymbolPosition: SymbolBefore, Decimals: 2, DecimalSeparator: ".", ThousandsSeparator: ","},
+			amount:   1234567.891,
+			expected: "£1,234,567.89",
+		},
+		{
+			name:     "symbol after with comma decimals",
+			currency: Currency{Symbol: "€", SymbolPosition: SymbolAfter, Decimals: 2, DecimalSeparator: ",", ThousandsSeparator: "."},
+			amount:   1299.5,
+			expected: "1.299,50 €",
+		},
+		{
+			name:     "no decimals",
+			currency: Currency{Symbol: "¥", SymbolPosition: SymbolBefore, Decimals: 0, ThousandsSeparator: ","},
+			amount:   12999.6,
+			expected: "¥13,000",
+		},
+		{
+			name:     "small amount",
+			currency: Currency{Symbol: "S$", Decimals: 2, DecimalSeparator: ".", ThousandsSeparator: ","},
+			amount:   39.99,
+			expected: "S$39.99",
+		},
+		{
+			name:     "negative amount",
+			currency: Currency{Symbol: "$", Decimals: 2, DecimalSeparator: ".", ThousandsSeparator: ","},
+			amount:   -1500,
+			expected: "$-1,500.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.currency.Format(tt.amount); got != tt.expected {
+				t.Errorf("Currency.Format() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+// Location is a datacenter location of the catalog, e.g. AmsterdamAMS-01 split into
+// the city Amsterdam and the datacenter code AMS-01. The code is empty when the
+// location doesn't end with one.
+type Location struct {
+	ID      uint   `json:"-" gorm:"primaryKey;autoIncrement;column:id"`
+	Name    string `json:"name" gorm:"type:varchar(128);not null;uniqueIndex;column:name"`
+	City    string `json:"city" gorm:"type:varchar(64);not null;index;column:city"`
+	Code    string `json:"code" gorm:"type:varchar(16);not null;index;column:code"`
+	Country string `json:"country" gorm:"type:varchar(64);not null;column:country"`
+}
+
+func (l *Location) TableName() string {
+	return "location"
+}
@@ -0,0 +1,12 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestLocation_TableName(t *testing.T) {
+	location := Location{}
+	if got := location.TableName(); got != "location" {
+		t.Errorf("Location.TableName() = %v, want %v", got, "location")
+	}
+}
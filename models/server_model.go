@@ -0,0 +1,15 @@
+package models
+
+// ServerModel is a model string of the catalog split into its vendor, chassis and CPU.
+// The parts are empty when the model string doesn't match the configured rules.
+type ServerModel struct {
+	ID      uint   `gorm:"primaryKey;autoIncrement;column:id"`
+	Name    string `gorm:"type:varchar(128);not null;uniqueIndex;column:name"`
+	Vendor  string `gorm:"type:varchar(64);not null;index;column:vendor"`
+	Chassis string `gorm:"type:varchar(64);not null;column:chassis"`
+	CPU     string `gorm:"type:varchar(128);not null;column:cpu"`
+}
+
+func (sm *ServerModel) TableName() string {
+	return "server_model"
+}
@@ -0,0 +1,12 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestServerModel_TableName(t *testing.T) {
+	model := ServerModel{}
+	if got := model.TableName(); got != "server_model" {
+		t.Errorf("ServerModel.TableName() = %v, want %v", got, "server_model")
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Upload outcomes
+const (
+	UploadOutcomeRunning   = "running"
+	UploadOutcomeSucceeded = "succeeded"
+	UploadOutcomeFailed    = "failed"
+	// UploadOutcomeDuplicate is recorded when a file matching the last successful upload was rejected
+	UploadOutcomeDuplicate = "duplicate"
+)
+
+// Upload sources
+const (
+	UploadSourceFile = "file"
+	UploadSourceBulk = "bulk"
+)
+
+// Upload records the provenance of a catalog upload: the file it came from, who
+// sent it and how it ended
+type Upload struct {
+	ID       uint   `gorm:"primaryKey;autoIncrement;column:id"`
+	Source   string `gorm:"type:varchar(16);not null;column:source"`
+	Filename string `gorm:"type:varchar(255);not null;column:filename"`
+	Size     int64  `gorm:"not null;column:size"`
+	// Checksum is the hex encoded SHA-256 of the uploaded content
+	Checksum  string     `gorm:"type:char(64);not null;index;column:checksum"`
+	Uploader  string     `gorm:"type:varchar(64);not null;column:uploader"`
+	Mode      string     `gorm:"type:varchar(16);not null;column:mode"`
+	Forced    bool       `gorm:"not null;column:forced"`
+	Outcome   string     `gorm:"type:varchar(16);not null;index;column:outcome"`
+	Error     string     `gorm:"type:text;column:error"`
+	Rows      int        `gorm:"not null;column:row_count"`
+	Inserted  int        `gorm:"not null;column:inserted"`
+	Updated   int        `gorm:"not null;column:updated"`
+	Unchanged int        `gorm:"not null;column:unchanged"`
+	Deleted   int        `gorm:"not null;column:deleted"`
+	VersionID *uint      `gorm:"column:version_id"`
+	StartedAt time.Time  `gorm:"not null;column:started_at"`
+	EndedAt   *time.Time `gorm:"column:ended_at"`
+}
+
+func (u *Upload) TableName() string {
+	return "upload"
+}
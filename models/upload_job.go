@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Upload job states
+const (
+	JobStateQueued    = "queued"
+	JobStateParsing   = "parsing"
+	JobStateInserting = "inserting"
+	JobStateDone      = "done"
+	JobStateFailed    = "failed"
+)
+
+// UploadJob tracks an asynchronous catalog upload
+type UploadJob struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement;column:id"`
+	State         string    `gorm:"type:varchar(16);not null;column:state"`
+	RowsProcessed int       `gorm:"not null;column:rows_processed"`
+	Error         string    `gorm:"type:text;column:error"`
+	Report        string    `gorm:"type:text;column:report"`
+	Result        string    `gorm:"type:text;column:result"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+	UpdatedAt     time.Time `gorm:"column:updated_at"`
+}
+
+func (uj *UploadJob) TableName() string {
+	return "upload_job"
+}
+
+// Finished reports whether the job reached a terminal state
+func (uj *UploadJob) Finished() bool {
+	return uj.State == JobStateDone || uj.State == JobStateFailed
+}
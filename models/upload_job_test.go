@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestUploadJob_TableName(t *testing.T) {
+	job := UploadJob{}
+	if got := job.TableName(); got != "upload_job" {
+		t.Errorf("UploadJob.TableName() = %v, want %v", got, "upload_job")
+	}
+}
+
+func TestUploadJob_Finished(t *testing.T) {
+	tests := []struct {
+		state    string
+		expected bool
+	}{
+		{state: JobStateQueued, expected: false},
+		{state: JobStateParsing, expected: false},
+		{state: JobStateInserting, expected: false},
+		{state: JobStateDone, expected: true},
+		{state: JobStateFailed, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			job := UploadJob{State: tt.state}
+			if got := job.Finished(); got != tt.expected {
+				t.Errorf("UploadJob.Finished() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
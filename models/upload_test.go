@@ -0,0 +1,12 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestUpload_TableName(t *testing.T) {
+	upload := Upload{}
+	if got := upload.TableName(); got != "upload" {
+		t.Errorf("Upload.TableName() = %v, want %v", got, "upload")
+	}
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+func (sc *ServerCatalog) CreateVersion(ctx context.Context, version *models.CatalogVersion) error {
+	if err := sc.db.WithContext(ctx).Create(version).Error; err != nil {
+		return fmt.Errorf("repository:catalog_version:: failed to create version %v", err)
+	}
+	return nil
+}
+
+func (sc *ServerCatalog) UpdateVersion(ctx context.Context, version *models.CatalogVersion) error {
+	if err := sc.db.WithContext(ctx).Save(version).Error; err != nil {
+		return fmt.Errorf("repository:catalog_version:: failed to update version %v", err)
+	}
+	return nil
+}
+
+// CopyVersion adds every server of version from to version to and returns how many
+// were added. The servers themselves are shared, a copy stores a membership row per
+// server and leaves the servers and their disks untouched.
+func (sc *ServerCatalog) CopyVersion(ctx context.Context, from, to uint) (int64, error) {
+	var tb models.CatalogVersionServer
+	res := sc.db.WithContext(ctx).Exec("INSERT INTO "+tb.TableName()+" (version_id, server_id) SELECT ?, server_id FROM "+
+		tb.TableName()+" WHERE version_id = ?", to, from)
+	if res.Error != nil {
+		return 0, fmt.Errorf("repository:catalog_version:: failed to copy version %v", res.Error)
+	}
+	return res.RowsAffected, nil
+}
+
+// linkServers adds servers to the versions they were inserted for
+func (sc *ServerCatalog) linkServers(ctx context.Context, members []models.CatalogVersionServer) error {
+	var tb models.CatalogVersionServer
+	if len(members) < 1 {
+		return nil
+	}
+	if err := sc.db.WithContext(ctx).Table(tb.TableName()).CreateInBatches(members, sc.batchSize).Error; err != nil {
+		return fmt.Errorf("repository:catalog_version:: failed to add servers to version %w", err)
+	}
+	return nil
+}
+
+// unlinkServers removes servers from a version, the servers stay in the other versions
+func (sc *ServerCatalog) unlinkServers(ctx context.Context, version uint, serverIDs []uint) error {
+	var tb models.CatalogVersionServer
+	if len(serverIDs) < 1 {
+		return nil
+	}
+	err := sc.db.WithContext(ctx).Where("version_id = ? AND server_id IN ?", version, serverIDs).Delete(&tb).Error
+	if err != nil {
+		return fmt.Errorf("repository:catalog_version:: failed to remove servers from version %w", err)
+	}
+	return nil
+}
+
+func (sc *ServerCatalog) GetVersions(ctx context.Context) ([]models.CatalogVersion, error) {
+	versions := []models.CatalogVersion{}
+	if err := sc.db.WithContext(ctx).Order("id DESC").Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("repository:catalog_version:: failed to fetch versions %v", err)
+	}
+	return versions, nil
+}
+
+func (sc *ServerCatalog) GetVersion(ctx context.Context, id uint) (*models.CatalogVersion, error) {
+	version := &models.CatalogVersion{}
+	err := sc.db.WithContext(ctx).First(version, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrVersionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("repository:catalog_version:: failed to fetch version %v", err)
+	}
+	return version, nil
+}
+
+// GetVersionServers returns every server of a version in insertion order
+func (sc *ServerCatalog) GetVersionServers(ctx context.Context, id uint) ([]models.ServerCatalog, error) {
+	var tb models.ServerCatalog
+	servers := []models.ServerCatalog{}
+	err := sc.db.WithContext(ctx).Table(tb.TableName()).Preload("Disks").Preload("ServerModel").
+		Where("id IN (?)", sc.versionServerIDs(id)).Order("id").Find(&servers).Error
+	if err != nil {
+		return nil, fmt.Errorf("repository:catalog_version:: failed to fetch servers of version %v", err)
+	}
+	return servers, nil
+}
+
+// GetActiveVersion returns the version served by the catalog, utils.ErrVersionNotFound
+// is returned while nothing was uploaded yet
+func (sc *ServerCatalog) GetActiveVersion(ctx context.Context) (*models.CatalogVersion, error) {
+	version := &models.CatalogVersion{}
+	err := sc.db.WithContext(ctx).Where("active = ?", true).First(version).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrVersionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("repository:catalog_version:: failed to fetch active version %v", err)
+	}
+	return version, nil
+}
+
+// ActivateVersion makes the given version the only active one
+func (sc *ServerCatalog) ActivateVersion(ctx context.Context, id uint) (*models.CatalogVersion, error) {
+	version := &models.CatalogVersion{}
+	err := sc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.First(version, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrVersionNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("repository:catalog_version:: failed to fetch version %v", err)
+		}
+
+		err = tx.Model(&models.CatalogVersion{}).Where("active = ? AND id <> ?", true, id).
+			Update("active", false).Error
+		if err != nil {
+			return fmt.Errorf("repository:catalog_version:: failed to deactivate versions %v", err)
+		}
+
+		now := time.Now()
+		version.Active = true
+		version.ActivatedAt = &now
+		if err := tx.Save(version).Error; err != nil {
+			return fmt.Errorf("repository:catalog_version:: failed to activate version %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// versionServerIDs selects the IDs of the servers of a version for use as a subquery
+func (sc *ServerCatalog) versionServerIDs(version uint) *gorm.DB {
+	var tb models.CatalogVersionServer
+	return sc.db.Table(tb.TableName()).Select("server_id").Where("version_id = ?", version)
+}
+
+// activeServerIDs selects the IDs of the servers of the active version for use as a subquery
+func (sc *ServerCatalog) activeServerIDs() *gorm.DB {
+	var tb models.CatalogVersionServer
+	return sc.db.Table(tb.TableName()).Select("server_id").Where("version_id = (?)", sc.activeVersion())
+}
+
+// activeVersion selects the ID of the active version for use as a subquery
+func (sc *ServerCatalog) activeVersion() *gorm.DB {
+	var tb models.CatalogVersion
+	return sc.db.Table(tb.TableName()).Select("id").Where("active = ?", true)
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestServerCatalog_CopyVersion(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	from := &models.CatalogVersion{Mode: "append"}
+	to := &models.CatalogVersion{Mode: "upsert"}
+	assert.NoError(t, repo.CreateVersion(ctx, from))
+	assert.NoError(t, repo.CreateVersion(ctx, to))
+
+	assert.NoError(t, repo.Upload(ctx, []models.ServerCatalog{
+		{Model: "Dell R210-II", RamSize: 16, Location: "Amsterdam", Price: 35.99, VersionID: from.ID,
+			Disks: []models.ServerDisk{{HDDCount: 2, HDDSize: 120, HDDType: 3}, {HDDCount: 4, HDDSize: 2048, HDDType: 1}}},
+		{Model: "HP DL120G7", RamSize: 32, Location: "Singapore", Price: 45.99, VersionID: from.ID},
+	}))
+
+	copied, err := repo.CopyVersion(ctx, from.ID, to.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), copied)
+
+	servers, err := repo.GetVersionServers(ctx, to.ID)
+	assert.NoError(t, err)
+	assert.Len(t, servers, 2)
+	assert.Equal(t, "Dell R210-II", servers[0].Model)
+	assert.Equal(t, 35.99, servers[0].Price)
+	assert.Len(t, servers[0].Disks, 2)
+	assert.Equal(t, 8432, servers[0].Storage())
+
+	// both versions share the servers and their disks
+	original, err := repo.GetVersionServers(ctx, from.ID)
+	assert.NoError(t, err)
+	assert.Len(t, original, 2)
+	assert.Equal(t, original[0].ID, servers[0].ID)
+	assert.Equal(t, original[0].Disks[0].ID, servers[0].Disks[0].ID)
+
+	var count int64
+	db.Model(&models.ServerCatalog{}).Count(&count)
+	assert.Equal(t, int64(2), count)
+	db.Model(&models.ServerDisk{}).Count(&count)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestServerCatalog_CopyVersion_Append(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	from := &models.CatalogVersion{Mode: "replace"}
+	assert.NoError(t, repo.CreateVersion(ctx, from))
+	servers := make([]models.ServerCatalog, 0, 100)
+	for i := 0; i < 100; i++ {
+		servers = append(servers, models.ServerCatalog{Model: fmt.Sprintf("Dell R%d", i), Location: "Amsterdam", VersionID: from.ID,
+			Disks: []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: 1}}})
+	}
+	assert.NoError(t, repo.Upload(ctx, servers))
+
+	// count the servers and disks written by the append
+	var written int64
+	err := db.Callback().Create().After("gorm:create").Register("test:count_rows", func(tx *gorm.DB) {
+		if tx.Statement.Table == "server_catalog" || tx.Statement.Table == "server_disk" {
+			written += tx.Statement.RowsAffected
+		}
+	})
+	assert.NoError(t, err)
+
+	to := &models.CatalogVersion{Mode: "append"}
+	assert.NoError(t, repo.CreateVersion(ctx, to))
+	copied, err := repo.CopyVersion(ctx, from.ID, to.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), copied)
+	assert.NoError(t, repo.Upload(ctx, []models.ServerCatalog{{Model: "HP DL120G7", Location: "Amsterdam", VersionID: to.ID,
+		Disks: []models.ServerDisk{{HDDCount: 4, HDDSize: 1024, HDDType: 1}}}}))
+
+	// the appended server and its disk group
+	assert.Equal(t, int64(2), written)
+
+	current, err := repo.GetVersionServers(ctx, to.ID)
+	assert.NoError(t, err)
+	assert.Len(t, current, 101)
+	previous, err := repo.GetVersionServers(ctx, from.ID)
+	assert.NoError(t, err)
+	assert.Len(t, previous, 100)
+}
+
+func TestServerCatalog_ActivateVersion(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	_, err := repo.GetActiveVersion(ctx)
+	assert.ErrorIs(t, err, utils.ErrVersionNotFound)
+
+	first := &models.CatalogVersion{Mode: "replace"}
+	second := &models.CatalogVersion{Mode: "append"}
+	assert.NoError(t, repo.CreateVersion(ctx, first))
+	assert.NoError(t, repo.CreateVersion(ctx, second))
+
+	_, err = repo.ActivateVersion(ctx, second.ID)
+	assert.NoError(t, err)
+
+	// rolling back re-activates the first version
+	version, err := repo.ActivateVersion(ctx, first.ID)
+	assert.NoError(t, err)
+	assert.True(t, version.Active)
+	assert.NotNil(t, version.ActivatedAt)
+
+	active, err := repo.GetActiveVersion(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, active.ID)
+
+	versions, err := repo.GetVersions(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, second.ID, versions[0].ID)
+	assert.False(t, versions[0].Active)
+
+	_, err = repo.ActivateVersion(ctx, 42)
+	assert.ErrorIs(t, err, utils.ErrVersionNotFound)
+
+	version, err = repo.GetVersion(ctx, second.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "append", version.Mode)
+
+	_, err = repo.GetVersion(ctx, 42)
+	assert.ErrorIs(t, err, utils.ErrVersionNotFound)
+}
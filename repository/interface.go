@@ -3,12 +3,46 @@ package repository
 import (
 	"context"
 	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
 	"github.com/server-catalog/models"
 )
 
 type CatalogRepository interface {
+	Transaction(ctx context.Context, fn func(repo CatalogRepository) error) error
 	Upload(ctx context.Context, servers []models.ServerCatalog) error
-	GetLocations(ctx context.Context) ([]string, error)
+	Upsert(ctx context.Context, servers []models.ServerCatalog) (inserted, updated, unchanged int, err error)
+	SaveModels(ctx context.Context, serverModels []*models.ServerModel) error
+	SaveLocations(ctx context.Context, locations []*models.Location) error
+	CreateVersion(ctx context.Context, version *models.CatalogVersion) error
+	UpdateVersion(ctx context.Context, version *models.CatalogVersion) error
+	CopyVersion(ctx context.Context, from, to uint) (int64, error)
+	GetVersions(ctx context.Context) ([]models.CatalogVersion, error)
+	GetVersion(ctx context.Context, id uint) (*models.CatalogVersion, error)
+	GetVersionServers(ctx context.Context, id uint) ([]models.ServerCatalog, error)
+	GetActiveVersion(ctx context.Context) (*models.CatalogVersion, error)
+	ActivateVersion(ctx context.Context, id uint) (*models.CatalogVersion, error)
+	CreateUpload(ctx context.Context, upload *models.Upload) error
+	UpdateUpload(ctx context.Context, upload *models.Upload) error
+	GetUploads(ctx context.Context, page *utils.Page) ([]models.Upload, error)
+	GetLastSuccessfulUpload(ctx context.Context) (*models.Upload, error)
+	GetLocations(ctx context.Context) ([]models.Location, error)
 	GetHDDTypes(ctx context.Context) ([]string, error)
 	GetServers(ctx context.Context, ctr *dto.ListServersCtr) ([]models.ServerCatalog, error)
+	StreamServers(ctx context.Context, ctr *dto.ListServersCtr, batchSize int, fn func(servers []models.ServerCatalog) error) error
+}
+
+type JobRepository interface {
+	CreateJob(ctx context.Context, job *models.UploadJob) error
+	UpdateJob(ctx context.Context, job *models.UploadJob) error
+	GetJob(ctx context.Context, id uint) (*models.UploadJob, error)
+	FailUnfinishedJobs(ctx context.Context, reason string) (int64, error)
+}
+
+type LookupRepository interface {
+	GetHDDSpecs(ctx context.Context) ([]models.HDDSpec, error)
+	GetRAMSpecs(ctx context.Context) ([]models.RamSpec, error)
+	GetCurrencies(ctx context.Context) ([]models.Currency, error)
+	CreateHDDSpec(ctx context.Context, spec *models.HDDSpec) error
+	CreateRAMSpec(ctx context.Context, spec *models.RamSpec) error
+	CreateCurrency(ctx context.Context, currency *models.Currency) error
 }
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/server-catalog/models"
+)
+
+// SaveLocations stores the split locations. Known names are kept as they are so
+// countries corrected in the database aren't overwritten by later uploads. Names are
+// matched case-insensitively like the unique index does, every location is set to
+// the stored one on return.
+func (sc *ServerCatalog) SaveLocations(ctx context.Context, locations []*models.Location) error {
+	if len(locations) < 1 {
+		return nil
+	}
+
+	names := make([]string, 0, len(locations))
+	for _, location := range locations {
+		names = append(names, location.Name)
+	}
+
+	existing := []models.Location{}
+	if err := sc.db.WithContext(ctx).Where("name IN ?", names).Find(&existing).Error; err != nil {
+		return fmt.Errorf("repository:location:: failed to fetch locations %v", err)
+	}
+	known := make(map[string]models.Location, len(existing))
+	for _, location := range existing {
+		known[nameKey(location.Name)] = location
+	}
+
+	inserts := make([]*models.Location, 0)
+	// names differing only in case are stored once, the later ones share the location
+	pending := make(map[string]*models.Location)
+	duplicates := make([]*models.Location, 0)
+	for _, location := range locations {
+		key := nameKey(location.Name)
+		if current, ok := known[key]; ok {
+			*location = current
+			continue
+		}
+		if _, ok := pending[key]; ok {
+			duplicates = append(duplicates, location)
+			continue
+		}
+		pending[key] = location
+		inserts = append(inserts, location)
+	}
+
+	if len(inserts) > 0 {
+		if err := sc.db.WithContext(ctx).Create(inserts).Error; err != nil {
+			return fmt.Errorf("repository:location:: failed to insert locations %v", err)
+		}
+	}
+	for _, location := range duplicates {
+		*location = *pending[nameKey(location.Name)]
+	}
+	return nil
+}
+
+// GetLocations returns the locations of the servers of the active catalog version
+func (sc *ServerCatalog) GetLocations(ctx context.Context) ([]models.Location, error) {
+	var tb models.ServerCatalog
+	locations := []models.Location{}
+	err := sc.db.WithContext(ctx).
+		Where("id IN (?)", sc.db.Table(tb.TableName()).Select("location_id").Where("id IN (?)", sc.activeServerIDs())).
+		Order("city").Order("code").Find(&locations).Error
+	if err != nil {
+		return nil, fmt.Errorf("repository:server_catalog:: failed to fetch server locations %v", err)
+	}
+	return locations, nil
+}
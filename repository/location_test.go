@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerCatalog_SaveLocations(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	amsterdam := &models.Location{Name: "AmsterdamAMS-01", City: "Amsterdam", Code: "AMS-01", Country: "Netherlands"}
+	assert.NoError(t, repo.SaveLocations(ctx, []*models.Location{amsterdam}))
+	assert.NotZero(t, amsterdam.ID)
+
+	// a known location keeps its country
+	again := &models.Location{Name: "AmsterdamAMS-01", City: "Amsterdam", Code: "AMS-01"}
+	dallas := &models.Location{Name: "DallasDAL-10", City: "Dallas", Code: "DAL-10", Country: "United States"}
+	assert.NoError(t, repo.SaveLocations(ctx, []*models.Location{again, dallas}))
+	assert.Equal(t, *amsterdam, *again)
+	assert.NotZero(t, dallas.ID)
+	assert.NotEqual(t, amsterdam.ID, dallas.ID)
+
+	var stored []models.Location
+	db.Order("id").Find(&stored)
+	assert.Equal(t, []models.Location{*amsterdam, *dallas}, stored)
+}
+
+func TestServerCatalog_SaveLocations_Case(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	// the unique index of the name ignores the case
+	amsterdam := &models.Location{Name: "AmsterdamAMS-01", City: "Amsterdam", Code: "AMS-01", Country: "Netherlands"}
+	lower := &models.Location{Name: "amsterdamAMS-01", City: "amsterdam", Code: "AMS-01", Country: "Netherlands"}
+	assert.NoError(t, repo.SaveLocations(ctx, []*models.Location{amsterdam, lower}))
+	assert.NotZero(t, amsterdam.ID)
+	assert.Equal(t, *amsterdam, *lower)
+
+	var count int64
+	db.Model(&models.Location{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestServerCatalog_GetServers_Locations(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	amsterdam := &models.Location{Name: "AmsterdamAMS-01", City: "Amsterdam", Code: "AMS-01", Country: "Netherlands"}
+	washington := &models.Location{Name: "Washington D.C.WDC-01", City: "Washington D.C.", Code: "WDC-01", Country: "United States"}
+	assert.NoError(t, repo.SaveLocations(ctx, []*models.Location{amsterdam, washington}))
+
+	versionID := createActiveVersion(t, db)
+	assert.NoError(t, repo.Upload(ctx, []models.ServerCatalog{
+		{Model: "Dell R210Intel Xeon X3440", Location: amsterdam.Name, LocationID: amsterdam.ID, VersionID: versionID},
+		{Model: "HP DL120G7Intel G850", Location: washington.Name, LocationID: washington.ID, VersionID: versionID},
+	}))
+
+	tests := []struct {
+		name     string
+		location string
+		expected []string
+	}{
+		{name: "filter by name", location: "AmsterdamAMS-01", expected: []string{"Dell R210Intel Xeon X3440"}},
+		{name: "filter by code", location: "WDC-01", expected: []string{"HP DL120G7Intel G850"}},
+		{name: "filter by city", location: "Washington D.C.", expected: []string{"HP DL120G7Intel G850"}},
+		{name: "unknown location", location: "AMS-02", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctr := &dto.ListServersCtr{
+				Location: &[]string{tt.location}[0],
+				Page:     &utils.Page{Limit: 10, Current: 1},
+			}
+			servers, err := repo.GetServers(ctx, ctr)
+			assert.NoError(t, err)
+
+			names := []string{}
+			for _, server := range servers {
+				names = append(names, server.Model)
+				assert.Equal(t, server.LocationID, server.DataCenter.ID)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
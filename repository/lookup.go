@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/server-catalog/models"
+	"gorm.io/gorm"
+)
+
+type Lookup struct {
+	db *gorm.DB
+}
+
+func NewLookup(db *gorm.DB) LookupRepository {
+	return &Lookup{db: db}
+}
+
+func (l *Lookup) GetHDDSpecs(ctx context.Context) ([]models.HDDSpec, error) {
+	specs := []models.HDDSpec{}
+	if err := l.db.WithContext(ctx).Order("id").Find(&specs).Error; err != nil {
+		return nil, fmt.Errorf("repository:lookup:: failed to fetch hdd specs %v", err)
+	}
+	return specs, nil
+}
+
+func (l *Lookup) GetRAMSpecs(ctx context.Context) ([]models.RamSpec, error) {
+	specs := []models.RamSpec{}
+	if err := l.db.WithContext(ctx).Order("id").Find(&specs).Error; err != nil {
+		return nil, fmt.Errorf("repository:lookup:: failed to fetch ram specs %v", err)
+	}
+	return specs, nil
+}
+
+func (l *Lookup) GetCurrencies(ctx context.Context) ([]models.Currency, error) {
+	currencies := []models.Currency{}
+	if err := l.db.WithContext(ctx).Order("id").Find(&currencies).Error; err != nil {
+		return nil, fmt.Errorf("repository:lookup:: failed to fetch currencies %v", err)
+	}
+	return currencies, nil
+}
+
+func (l *Lookup) CreateHDDSpec(ctx context.Context, spec *models.HDDSpec) error {
+	if err := l.db.WithContext(ctx).Create(spec).Error; err != nil {
+		return fmt.Errorf("repository:lookup:: failed to create hdd spec %v", err)
+	}
+	return nil
+}
+
+func (l *Lookup) CreateRAMSpec(ctx context.Context, spec *models.RamSpec) error {
+	if err := l.db.WithContext(ctx).Create(spec).Error; err != nil {
+		return fmt.Errorf("repository:lookup:: failed to create ram spec %v", err)
+	}
+	return nil
+}
+
+func (l *Lookup) CreateCurrency(ctx context.Context, currency *models.Currency) error {
+	if err := l.db.WithContext(ctx).Create(currency).Error; err != nil {
+		return fmt.Errorf("repository:lookup:: failed to create currency %v", err)
+	}
+	return nil
+}
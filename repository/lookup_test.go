@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/server-catalog/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup_Specs(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&models.RamSpec{}, &models.Currency{}))
+	repo := NewLookup(db)
+	ctx := context.Background()
+
+	nvme := &models.HDDSpec{Type: "NVMe"}
+	assert.NoError(t, repo.CreateHDDSpec(ctx, &models.HDDSpec{Type: "SATA2"}))
+	assert.NoError(t, repo.CreateHDDSpec(ctx, nvme))
+	assert.NotZero(t, nvme.ID)
+
+	hddSpecs, err := repo.GetHDDSpecs(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.HDDSpec{{ID: 1, Type: "SATA2"}, {ID: nvme.ID, Type: "NVMe"}}, hddSpecs)
+
+	ddr5 := &models.RamSpec{Type: "DDR5"}
+	assert.NoError(t, repo.CreateRAMSpec(ctx, ddr5))
+	ramSpecs, err := repo.GetRAMSpecs(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.RamSpec{*ddr5}, ramSpecs)
+}
+
+func TestLookup_Currencies(t *testing.T) {
+	db := setupTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&models.Currency{}))
+	repo := NewLookup(db)
+	ctx := context.Background()
+
+	gbp := &models.Currency{Code: "GBP", Type: "Pound Sterling", Symbol: "£"}
+	assert.NoError(t, repo.CreateCurrency(ctx, gbp))
+	assert.NotZero(t, gbp.ID)
+	// codes and symbols are unique
+	assert.Error(t, repo.CreateCurrency(ctx, &models.Currency{Code: "GBP", Type: "Pound", Symbol: "GB£"}))
+	assert.Error(t, repo.CreateCurrency(ctx, &models.Currency{Code: "EGP", Type: "Egyptian Pound", Symbol: "£"}))
+
+	currencies, err := repo.GetCurrencies(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Currency{*gbp}, currencies)
+}
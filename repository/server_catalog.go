@@ -4,37 +4,154 @@ import (
 	"context"
 	"fmt"
 	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
 	"github.com/server-catalog/models"
 	"gorm.io/gorm"
 )
 
 type ServerCatalog struct {
 	db *gorm.DB
+	// batchSize is the number of servers written per INSERT statement
+	batchSize int
 }
 
-func NewServerCatalog(db *gorm.DB) CatalogRepository {
-	return &ServerCatalog{db: db}
+// defaultInsertBatchSize keeps the INSERT statements well below the placeholder limit of MySQL
+const defaultInsertBatchSize = 500
+
+// NewServerCatalog returns the catalog repository, servers are inserted in batches
+// of batchSize or of defaultInsertBatchSize when it isn't positive
+func NewServerCatalog(db *gorm.DB, batchSize int) CatalogRepository {
+	if batchSize < 1 {
+		batchSize = defaultInsertBatchSize
+	}
+	return &ServerCatalog{db: db, batchSize: batchSize}
 }
 
+// Transaction runs fn against a repository bound to a single database transaction.
+// The transaction is rolled back when fn returns an error or ctx is canceled.
+func (sc *ServerCatalog) Transaction(ctx context.Context, fn func(repo CatalogRepository) error) error {
+	return sc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&ServerCatalog{db: tx, batchSize: sc.batchSize})
+	})
+}
+
+// Upload inserts the servers and their disks in batches and adds them to their
+// version. It doesn't open a transaction of its own, run it through Transaction
+// to store the batches atomically.
 func (sc *ServerCatalog) Upload(ctx context.Context, servers []models.ServerCatalog) error {
 	var tb models.ServerCatalog
-	return sc.db.Table(tb.TableName()).Create(servers).Error
+	if len(servers) < 1 {
+		return nil
+	}
+	if err := sc.db.WithContext(ctx).Table(tb.TableName()).CreateInBatches(servers, sc.batchSize).Error; err != nil {
+		return fmt.Errorf("repository:server_catalog:: failed to insert servers %w", err)
+	}
+
+	members := make([]models.CatalogVersionServer, 0, len(servers))
+	for _, server := range servers {
+		members = append(members, models.CatalogVersionServer{VersionID: server.VersionID, ServerID: server.ID})
+	}
+	return sc.linkServers(ctx, members)
 }
 
-func (sc *ServerCatalog) GetLocations(ctx context.Context) ([]string, error) {
+// Upsert matches the servers on their natural key (model, RAM, disks and location)
+// within the catalog version they belong to. Matching servers get their price
+// updated, the others are inserted. A server shared with older versions isn't
+// updated in place, the version swaps it for a repriced copy. When servers of the
+// batch share a key the last price wins and counts as an update. All servers must
+// belong to the same version.
+func (sc *ServerCatalog) Upsert(ctx context.Context, servers []models.ServerCatalog) (int, int, int, error) {
 	var tb models.ServerCatalog
-	locations := []string{}
-	err := sc.db.Table(tb.TableName()).Select("DISTINCT location").Order("location").Pluck("location", &locations).Error
+	if len(servers) < 1 {
+		return 0, 0, 0, nil
+	}
+
+	modelNames := make([]string, 0, len(servers))
+	locations := make([]string, 0, len(servers))
+	for _, server := range servers {
+		modelNames = append(modelNames, server.Model)
+		locations = append(locations, server.Location)
+	}
+
+	version := servers[0].VersionID
+	candidates := []models.ServerCatalog{}
+	err := sc.db.WithContext(ctx).Table(tb.TableName()).Preload("Disks").
+		Where("id IN (?) AND model IN ? AND location IN ?", sc.versionServerIDs(version), modelNames, locations).
+		Find(&candidates).Error
 	if err != nil {
-		return nil, fmt.Errorf("repository:server_catalog:: failed to fetch server locations %v", err)
+		return 0, 0, 0, fmt.Errorf("repository:server_catalog:: failed to fetch existing servers %v", err)
+	}
+
+	existing := make(map[string]*models.ServerCatalog, len(candidates))
+	for i := range candidates {
+		existing[candidates[i].NaturalKey()] = &candidates[i]
+	}
+
+	var inserted, updated, unchanged int
+	inserts := make([]*models.ServerCatalog, 0)
+	// shared servers swapped for a repriced copy
+	replaced := make([]uint, 0)
+	for _, server := range servers {
+		current, ok := existing[server.NaturalKey()]
+		if !ok {
+			pending := server
+			inserts = append(inserts, &pending)
+			existing[server.NaturalKey()] = &pending
+			inserted++
+			continue
+		}
+
+		if current.Price == server.Price && current.Currency == server.Currency {
+			unchanged++
+			continue
+		}
+		updated++
+		if current.ID == 0 {
+			// a duplicate of a server inserted by this batch with another price, the
+			// pending insert takes the price of the last row like a stored server would
+			current.Price = server.Price
+			current.Currency = server.Currency
+			continue
+		}
+		if current.VersionID != version {
+			pending := server
+			inserts = append(inserts, &pending)
+			existing[server.NaturalKey()] = &pending
+			replaced = append(replaced, current.ID)
+			continue
+		}
+
+		// inserted by this version, no other version shares it
+		err := sc.db.WithContext(ctx).Table(tb.TableName()).Where("id = ?", current.ID).
+			Updates(map[string]interface{}{"price": server.Price, "currency": server.Currency}).Error
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("repository:server_catalog:: failed to update server %v", err)
+		}
+	}
+
+	if err := sc.unlinkServers(ctx, version, replaced); err != nil {
+		return 0, 0, 0, err
+	}
+	if len(inserts) > 0 {
+		if err := sc.db.WithContext(ctx).Table(tb.TableName()).CreateInBatches(inserts, sc.batchSize).Error; err != nil {
+			return 0, 0, 0, fmt.Errorf("repository:server_catalog:: failed to insert servers %w", err)
+		}
+		members := make([]models.CatalogVersionServer, 0, len(inserts))
+		for _, server := range inserts {
+			members = append(members, models.CatalogVersionServer{VersionID: version, ServerID: server.ID})
+		}
+		if err := sc.linkServers(ctx, members); err != nil {
+			return 0, 0, 0, err
+		}
 	}
-	return locations, nil
+
+	return inserted, updated, unchanged, nil
 }
 
 func (sc *ServerCatalog) GetHDDTypes(ctx context.Context) ([]string, error) {
 	var hs models.HDDSpec
 	types := []string{}
-	err := sc.db.Table(hs.TableName()).Select("DISTINCT type").Order("type").Pluck("type", &types).Error
+	err := sc.db.WithContext(ctx).Table(hs.TableName()).Select("DISTINCT type").Order("type").Pluck("type", &types).Error
 	if err != nil {
 		return nil, fmt.Errorf("repository:server_catalog:: failed to fetch hdd specs %v", err)
 	}
@@ -42,19 +159,61 @@ func (sc *ServerCatalog) GetHDDTypes(ctx context.Context) ([]string, error) {
 }
 
 func (sc *ServerCatalog) GetServers(ctx context.Context, ctr *dto.ListServersCtr) ([]models.ServerCatalog, error) {
-	m := models.ServerCatalog{}
 	res := []models.ServerCatalog{}
-	qry := sc.db.Table(m.TableName())
 
+	// the total counts every server matching the filters, not only those of the page
 	var count int64
-	if err := qry.Count(&count).Error; err != nil {
+	if err := sc.filterServers(sc.versionServers(ctr), ctr).WithContext(ctx).Count(&count).Error; err != nil {
 		return nil, fmt.Errorf("repository:server_catalog:: failed to fetch count of servers %v", err)
 	}
 
 	ctr.Page.Total = int(count)
 
+	qry, err := sc.sortServers(sc.filterServers(sc.versionServers(ctr), ctr), ctr.Sort)
+	if err != nil {
+		return nil, err
+	}
+	if err := qry.WithContext(ctx).Preload("Disks").Preload("ServerModel").Preload("DataCenter").Limit(ctr.Page.Limit).Offset(ctr.Page.Offset()).Find(&res).Error; err != nil {
+		return nil, fmt.Errorf("repository:server_catalog:: failed to fetch  servers %v", err)
+	}
+
+	return res, nil
+}
+
+// StreamServers hands the servers matching the filters of ctr to fn in batches of
+// batchSize, ordered by ID. The page of ctr is ignored.
+func (sc *ServerCatalog) StreamServers(ctx context.Context, ctr *dto.ListServersCtr, batchSize int, fn func(servers []models.ServerCatalog) error) error {
+	batch := []models.ServerCatalog{}
+	res := sc.filterServers(sc.versionServers(ctr), ctr).WithContext(ctx).
+		Preload("Disks").Preload("ServerModel").Preload("DataCenter").
+		FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		})
+	if res.Error != nil {
+		return fmt.Errorf("repository:server_catalog:: failed to stream servers %v", res.Error)
+	}
+	return nil
+}
+
+// versionServers selects the servers of the version requested by ctr, the active one by default
+func (sc *ServerCatalog) versionServers(ctr *dto.ListServersCtr) *gorm.DB {
+	m := models.ServerCatalog{}
+	qry := sc.db.Table(m.TableName())
+	if ctr.Version != nil {
+		return qry.Where(m.TableName()+".id IN (?)", sc.versionServerIDs(*ctr.Version))
+	}
+	return qry.Where(m.TableName()+".id IN (?)", sc.activeServerIDs())
+}
+
+// filterServers narrows qry down to the servers matching the filters of ctr
+func (sc *ServerCatalog) filterServers(qry *gorm.DB, ctr *dto.ListServersCtr) *gorm.DB {
+	m := models.ServerCatalog{}
+	disk := models.ServerDisk{}
+	sm := models.ServerModel{}
+	loc := models.Location{}
+
 	if ctr.StorageMin != nil || ctr.StorageMax != nil {
-		storageQuery := "hdd_size * hdd_count"
+		storageQuery := storageExpr()
 		if ctr.StorageMin != nil {
 			qry = qry.Where(storageQuery+" >= ?", *ctr.StorageMin)
 		}
@@ -67,17 +226,78 @@ func (sc *ServerCatalog) GetServers(ctx context.Context, ctr *dto.ListServersCtr
 		qry = qry.Where("ram_size IN ?", ctr.RAM)
 	}
 
+	if ctr.RAMType != nil {
+		qry = qry.Where("ram_type = ?", *ctr.RAMType)
+	}
+
+	if ctr.ECC != nil {
+		qry = qry.Where("ram_ecc = ?", *ctr.ECC)
+	}
+
 	if ctr.HDD != nil {
-		qry = qry.Where("hdd_type = ?", *ctr.HDD)
+		// a server matches when any of its disk groups has the type
+		qry = qry.Where("EXISTS (SELECT 1 FROM "+disk.TableName()+" WHERE server_id = "+m.TableName()+".id AND hdd_type = ?)", *ctr.HDD)
 	}
 
 	if ctr.Location != nil {
-		qry = qry.Where("location = ?", *ctr.Location)
+		// matches the location as uploaded, its datacenter code (AMS-01) or its city
+		qry = qry.Where("location_id IN (?)", sc.db.Table(loc.TableName()).Select("id").
+			Where("name = ? OR code = ? OR city = ?", *ctr.Location, *ctr.Location, *ctr.Location))
 	}
 
-	if err := qry.WithContext(ctx).Limit(ctr.Page.Limit).Offset(ctr.Page.Offset()).Find(&res).Error; err != nil {
-		return nil, fmt.Errorf("repository:server_catalog:: failed to fetch  servers %v", err)
+	if ctr.Vendor != nil {
+		qry = qry.Where("model_id IN (?)", sc.db.Table(sm.TableName()).Select("id").Where("vendor = ?", *ctr.Vendor))
 	}
 
-	return res, nil
+	if ctr.CPU != nil {
+		// matches any part of the CPU, e.g. E5-2650 for 2x Intel Xeon E5-2650V4
+		qry = qry.Where("model_id IN (?)", sc.db.Table(sm.TableName()).Select("id").Where("cpu LIKE ?", "%"+*ctr.CPU+"%"))
+	}
+
+	if ctr.Currency != nil {
+		qry = qry.Where("currency = ?", *ctr.Currency)
+	}
+
+	if ctr.MinPrice != nil {
+		qry = qry.Where("price >= ?", *ctr.MinPrice)
+	}
+
+	if ctr.MaxPrice != nil {
+		qry = qry.Where("price <= ?", *ctr.MaxPrice)
+	}
+
+	return qry
+}
+
+// sortServers orders qry by the sort keys, with the ID as the last key so that pages
+// never overlap when servers share the same values
+func (sc *ServerCatalog) sortServers(qry *gorm.DB, keys []utils.SortKey) (*gorm.DB, error) {
+	m := models.ServerCatalog{}
+	columns := map[string]string{
+		utils.SortPrice:    "price",
+		utils.SortRAM:      "ram_size",
+		utils.SortStorage:  storageExpr(),
+		utils.SortModel:    "model",
+		utils.SortLocation: "location",
+	}
+
+	for _, key := range keys {
+		column, ok := columns[key.Key]
+		if !ok {
+			return nil, fmt.Errorf("repository:server_catalog:: unknown sort key %s", key.Key)
+		}
+		if key.Desc {
+			column += " DESC"
+		}
+		qry = qry.Order(column)
+	}
+	return qry.Order(m.TableName() + ".id"), nil
+}
+
+// storageExpr sums the capacity of every disk group of a server in GB
+func storageExpr() string {
+	m := models.ServerCatalog{}
+	disk := models.ServerDisk{}
+	return "(SELECT COALESCE(SUM(hdd_count * hdd_size), 0) FROM " + disk.TableName() +
+		" WHERE server_id = " + m.TableName() + ".id)"
 }
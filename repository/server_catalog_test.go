@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/server-catalog/internal/dto"
@@ -16,15 +17,30 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	assert.NoError(t, err)
 
-	err = db.AutoMigrate(&models.ServerCatalog{}, &models.HDDSpec{})
+	err = db.AutoMigrate(&models.ServerCatalog{}, &models.ServerDisk{}, &models.ServerModel{}, &models.Location{}, &models.HDDSpec{}, &models.UploadJob{}, &models.CatalogVersion{}, &models.CatalogVersionServer{}, &models.Upload{})
 	assert.NoError(t, err)
 
 	return db
 }
 
+// linkTestServers adds the servers created directly in the database to the version
+// that inserted them, like the migration does for the existing catalogs
+func linkTestServers(t *testing.T, db *gorm.DB) {
+	err := db.Exec("INSERT INTO catalog_version_server (version_id, server_id) SELECT version_id, id FROM server_catalog " +
+		"WHERE id NOT IN (SELECT server_id FROM catalog_version_server)").Error
+	assert.NoError(t, err)
+}
+
+// createActiveVersion creates the catalog version served by the read queries
+func createActiveVersion(t *testing.T, db *gorm.DB) uint {
+	version := models.CatalogVersion{Mode: "append", Active: true}
+	assert.NoError(t, db.Create(&version).Error)
+	return version.ID
+}
+
 func TestServerCatalog_Upload(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewServerCatalog(db)
+	repo := NewServerCatalog(db, 0)
 	ctx := context.Background()
 
 	// tests ....
@@ -33,9 +49,7 @@ func TestServerCatalog_Upload(t *testing.T) {
 			Model:    "Dell R210-II",
 			RamSize:  16,
 			RamType:  1,
-			HDDSize:  500,
-			HDDCount: 2,
-			HDDType:  1,
+			Disks:    []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: 1}},
 			Location: "Amsterdam",
 			Price:    35.99,
 			Currency: 1,
@@ -44,9 +58,7 @@ func TestServerCatalog_Upload(t *testing.T) {
 			Model:    "HP DL120G7",
 			RamSize:  32,
 			RamType:  2,
-			HDDSize:  1000,
-			HDDCount: 4,
-			HDDType:  2,
+			Disks:    []models.ServerDisk{{HDDCount: 4, HDDSize: 1000, HDDType: 2}},
 			Location: "Singapore",
 			Price:    45.99,
 			Currency: 1,
@@ -67,31 +79,222 @@ func TestServerCatalog_Upload(t *testing.T) {
 	assert.Equal(t, "Amsterdam", result.Location)
 }
 
+func TestServerCatalog_Upsert(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	existing := []models.ServerCatalog{
+		{Model: "Dell R210-II", RamSize: 16, RamType: 1, Disks: []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: 1}}, Location: "Amsterdam", Price: 35.99, Currency: 1},
+		{Model: "HP DL120G7", RamSize: 32, RamType: 2, Disks: []models.ServerDisk{{HDDCount: 4, HDDSize: 1000, HDDType: 2}}, Location: "Singapore", Price: 45.99, Currency: 1},
+	}
+	assert.NoError(t, repo.Upload(ctx, existing))
+
+	inserted, updated, unchanged, err := repo.Upsert(ctx, []models.ServerCatalog{
+		// price changed
+		{Model: "Dell R210-II", RamSize: 16, RamType: 1, Disks: []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: 1}}, Location: "Amsterdam", Price: 29.99, Currency: 1},
+		// same price
+		{Model: "HP DL120G7", RamSize: 32, RamType: 2, Disks: []models.ServerDisk{{HDDCount: 4, HDDSize: 1000, HDDType: 2}}, Location: "Singapore", Price: 45.99, Currency: 1},
+		// other location
+		{Model: "HP DL120G7", RamSize: 32, RamType: 2, Disks: []models.ServerDisk{{HDDCount: 4, HDDSize: 1000, HDDType: 2}}, Location: "Amsterdam", Price: 49.99, Currency: 2},
+		// duplicate of the new server
+		{Model: "HP DL120G7", RamSize: 32, RamType: 2, Disks: []models.ServerDisk{{HDDCount: 4, HDDSize: 1000, HDDType: 2}}, Location: "Amsterdam", Price: 49.99, Currency: 2},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, inserted)
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, 2, unchanged)
+
+	var count int64
+	db.Model(&models.ServerCatalog{}).Count(&count)
+	assert.Equal(t, int64(3), count)
+
+	var result models.ServerCatalog
+	db.First(&result, "model = ? AND location = ?", "Dell R210-II", "Amsterdam")
+	assert.Equal(t, 29.99, result.Price)
+}
+
+func TestServerCatalog_Upsert_SharedServer(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	from := &models.CatalogVersion{Mode: "append"}
+	to := &models.CatalogVersion{Mode: "upsert"}
+	assert.NoError(t, repo.CreateVersion(ctx, from))
+	assert.NoError(t, repo.CreateVersion(ctx, to))
+	assert.NoError(t, repo.Upload(ctx, []models.ServerCatalog{
+		{Model: "Dell R210-II", RamSize: 16, RamType: 1, Disks: []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: 1}}, Location: "Amsterdam", Price: 35.99, Currency: 1, VersionID: from.ID},
+		{Model: "HP DL120G7", RamSize: 32, RamType: 2, Disks: []models.ServerDisk{{HDDCount: 4, HDDSize: 1000, HDDType: 2}}, Location: "Singapore", Price: 45.99, Currency: 1, VersionID: from.ID},
+	}))
+	_, err := repo.CopyVersion(ctx, from.ID, to.ID)
+	assert.NoError(t, err)
+
+	inserted, updated, unchanged, err := repo.Upsert(ctx, []models.ServerCatalog{
+		{Model: "Dell R210-II", RamSize: 16, RamType: 1, Disks: []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: 1}}, Location: "Amsterdam", Price: 29.99, Currency: 1, VersionID: to.ID},
+		{Model: "HP DL120G7", RamSize: 32, RamType: 2, Disks: []models.ServerDisk{{HDDCount: 4, HDDSize: 1000, HDDType: 2}}, Location: "Singapore", Price: 45.99, Currency: 1, VersionID: to.ID},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, inserted)
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, 1, unchanged)
+
+	// the repriced server is a copy of its own, the unchanged one stays shared
+	previous, err := repo.GetVersionServers(ctx, from.ID)
+	assert.NoError(t, err)
+	current, err := repo.GetVersionServers(ctx, to.ID)
+	assert.NoError(t, err)
+	assert.Len(t, current, 2)
+	assert.Equal(t, 35.99, previous[0].Price)
+	assert.Equal(t, previous[1].ID, current[0].ID)
+	assert.Equal(t, "Dell R210-II", current[1].Model)
+	assert.Equal(t, 29.99, current[1].Price)
+	assert.Len(t, current[1].Disks, 1)
+
+	var count int64
+	db.Model(&models.ServerCatalog{}).Count(&count)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestServerCatalog_Upsert_DuplicateKey(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	inserted, updated, unchanged, err := repo.Upsert(ctx, []models.ServerCatalog{
+		{Model: "Dell R210-II", RamSize: 16, RamType: 1, Disks: []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: 1}}, Location: "Amsterdam", Price: 35.99, Currency: 1},
+		// same key, other price
+		{Model: "Dell R210-II", RamSize: 16, RamType: 1, Disks: []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: 1}}, Location: "Amsterdam", Price: 29.99, Currency: 1},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, inserted)
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, 0, unchanged)
+
+	var result []models.ServerCatalog
+	db.Find(&result, "model = ?", "Dell R210-II")
+	assert.Len(t, result, 1)
+	assert.Equal(t, 29.99, result[0].Price)
+}
+
+func TestServerCatalog_Transaction(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	// a failing upload must not leave a version or servers behind
+	err := repo.Transaction(ctx, func(tx CatalogRepository) error {
+		version := &models.CatalogVersion{Mode: "replace"}
+		if err := tx.CreateVersion(ctx, version); err != nil {
+			return err
+		}
+		if err := tx.Upload(ctx, []models.ServerCatalog{{Model: "HP DL120G7", VersionID: version.ID}}); err != nil {
+			return err
+		}
+		return utils.ErrUploadFailed
+	})
+	assert.ErrorIs(t, err, utils.ErrUploadFailed)
+
+	var count int64
+	db.Model(&models.ServerCatalog{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+	db.Model(&models.CatalogVersion{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestServerCatalog_Upload_Batches(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 2)
+	ctx := context.Background()
+
+	versionID := createActiveVersion(t, db)
+	servers := []models.ServerCatalog{}
+	for _, model := range []string{"A", "B", "C", "D", "E"} {
+		servers = append(servers, models.ServerCatalog{
+			Model:     model,
+			Disks:     []models.ServerDisk{{HDDCount: 2, HDDSize: 120, HDDType: 3}, {HDDCount: 4, HDDSize: 2048, HDDType: 1}},
+			VersionID: versionID,
+		})
+	}
+
+	// count the INSERT statements of the servers, their disks are inserted along with every batch
+	var statements int
+	err := db.Callback().Create().After("gorm:create").Register("test:count_inserts", func(tx *gorm.DB) {
+		if tx.Statement.Table == "server_catalog" {
+			statements++
+		}
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, repo.Upload(ctx, servers))
+	assert.Equal(t, 3, statements)
+
+	var count int64
+	db.Model(&models.ServerCatalog{}).Count(&count)
+	assert.Equal(t, int64(5), count)
+	db.Model(&models.ServerDisk{}).Count(&count)
+	assert.Equal(t, int64(10), count)
+}
+
+func TestServerCatalog_Transaction_Canceled(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// canceling the request mid-way rolls back the batches stored so far
+	err := repo.Transaction(ctx, func(tx CatalogRepository) error {
+		version := &models.CatalogVersion{Mode: "replace"}
+		if err := tx.CreateVersion(ctx, version); err != nil {
+			return err
+		}
+		if err := tx.Upload(ctx, []models.ServerCatalog{{Model: "A", VersionID: version.ID}, {Model: "B", VersionID: version.ID}}); err != nil {
+			return err
+		}
+		cancel()
+		return tx.Upload(ctx, []models.ServerCatalog{{Model: "C", VersionID: version.ID}})
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	var count int64
+	db.Model(&models.ServerCatalog{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+	db.Model(&models.CatalogVersion{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
 func TestServerCatalog_GetLocations(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewServerCatalog(db)
+	repo := NewServerCatalog(db, 0)
 	ctx := context.Background()
 
+	amsterdam := &models.Location{Name: "AmsterdamAMS-01", City: "Amsterdam", Code: "AMS-01", Country: "Netherlands"}
+	singapore := &models.Location{Name: "SingaporeSIN-11", City: "Singapore", Code: "SIN-11", Country: "Singapore"}
+	london := &models.Location{Name: "London", City: "London"}
+	dallas := &models.Location{Name: "DallasDAL-10", City: "Dallas", Code: "DAL-10", Country: "United States"}
+	assert.NoError(t, repo.SaveLocations(ctx, []*models.Location{amsterdam, singapore, london, dallas}))
+
 	// Insert test data
+	versionID := createActiveVersion(t, db)
 	testData := []models.ServerCatalog{
-		{Location: "Amsterdam"},
-		{Location: "Singapore"},
-		{Location: "Amsterdam"},
-		{Location: "London"},
+		{Location: amsterdam.Name, LocationID: amsterdam.ID, VersionID: versionID},
+		{Location: singapore.Name, LocationID: singapore.ID, VersionID: versionID},
+		{Location: amsterdam.Name, LocationID: amsterdam.ID, VersionID: versionID},
+		{Location: london.Name, LocationID: london.ID, VersionID: versionID},
+		// servers of inactive versions are not listed
+		{Location: dallas.Name, LocationID: dallas.ID, VersionID: versionID + 1},
 	}
 	db.Create(&testData)
+	linkTestServers(t, db)
 
 	locations, err := repo.GetLocations(ctx)
 	assert.NoError(t, err)
-	assert.Len(t, locations, 3)
-	assert.Contains(t, locations, "Amsterdam")
-	assert.Contains(t, locations, "Singapore")
-	assert.Contains(t, locations, "London")
+	assert.Equal(t, []models.Location{*amsterdam, *london, *singapore}, locations)
 }
 
 func TestServerCatalog_GetHDDTypes(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewServerCatalog(db)
+	repo := NewServerCatalog(db, 0)
 	ctx := context.Background()
 
 	testData := []models.HDDSpec{
@@ -113,35 +316,49 @@ func TestServerCatalog_GetHDDTypes(t *testing.T) {
 
 func TestServerCatalog_GetServers(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewServerCatalog(db)
+	repo := NewServerCatalog(db, 0)
 	ctx := context.Background()
 
+	amsterdam := &models.Location{Name: "Amsterdam", City: "Amsterdam"}
+	singapore := &models.Location{Name: "Singapore", City: "Singapore"}
+	assert.NoError(t, repo.SaveLocations(ctx, []*models.Location{amsterdam, singapore}))
+
+	versionID := createActiveVersion(t, db)
 	testData := []models.ServerCatalog{
 		{
-			Model:    "Server 1",
-			RamSize:  16,
-			HDDSize:  500,
-			HDDCount: 2,
-			HDDType:  1, // SATA2
-			Location: "Amsterdam",
-			Price:    35.99,
+			Model:      "Server 1",
+			RamSize:    16,
+			Disks:      []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: 1}}, // SATA2
+			Location:   "Amsterdam",
+			LocationID: amsterdam.ID,
+			Price:      35.99,
+			VersionID:  versionID,
 		},
 		{
-			Model:    "Server 2",
-			RamSize:  32,
-			HDDSize:  1000,
-			HDDCount: 4,
-			HDDType:  2, // SAS
-			Location: "Singapore",
-			Price:    45.99,
+			Model:      "Server 2",
+			RamSize:    32,
+			Disks:      []models.ServerDisk{{HDDCount: 4, HDDSize: 1000, HDDType: 2}}, // SAS
+			Location:   "Singapore",
+			LocationID: singapore.ID,
+			Price:      45.99,
+			VersionID:  versionID,
+		},
+		{
+			Model:      "Server 3",
+			RamSize:    16,
+			Location:   "Amsterdam",
+			LocationID: amsterdam.ID,
+			VersionID:  versionID + 1,
 		},
 	}
 	db.Create(&testData)
+	linkTestServers(t, db)
 
 	tests := []struct {
 		name          string
 		ctr           *dto.ListServersCtr
 		expectedCount int
+		expectedTotal int
 	}{
 		{
 			name: "filter by RAM",
@@ -153,6 +370,7 @@ func TestServerCatalog_GetServers(t *testing.T) {
 				},
 			},
 			expectedCount: 1,
+			expectedTotal: 1,
 		},
 		{
 			name: "filter by HDD type",
@@ -164,6 +382,7 @@ func TestServerCatalog_GetServers(t *testing.T) {
 				},
 			},
 			expectedCount: 1,
+			expectedTotal: 1,
 		},
 		{
 			name: "filter by location",
@@ -175,6 +394,7 @@ func TestServerCatalog_GetServers(t *testing.T) {
 				},
 			},
 			expectedCount: 1,
+			expectedTotal: 1,
 		},
 		{
 			name: "filter by storage range",
@@ -187,6 +407,7 @@ func TestServerCatalog_GetServers(t *testing.T) {
 				},
 			},
 			expectedCount: 1,
+			expectedTotal: 2,
 		},
 		{
 			name: "pagination test",
@@ -197,6 +418,7 @@ func TestServerCatalog_GetServers(t *testing.T) {
 				},
 			},
 			expectedCount: 1,
+			expectedTotal: 2,
 		},
 	}
 
@@ -206,14 +428,288 @@ func TestServerCatalog_GetServers(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Len(t, servers, tt.expectedCount)
 
-			assert.Equal(t, 2, tt.ctr.Page.Total)
+			assert.Equal(t, tt.expectedTotal, tt.ctr.Page.Total)
 		})
 	}
+
+	t.Run("inactive version", func(t *testing.T) {
+		ctr := &dto.ListServersCtr{
+			Version: &[]uint{versionID + 1}[0],
+			Page: &utils.Page{
+				Limit:   10,
+				Current: 1,
+			},
+		}
+		servers, err := repo.GetServers(ctx, ctr)
+		assert.NoError(t, err)
+		assert.Len(t, servers, 1)
+		assert.Equal(t, "Server 3", servers[0].Model)
+	})
+}
+
+func TestServerCatalog_GetServers_Disks(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	versionID := createActiveVersion(t, db)
+	assert.NoError(t, repo.Upload(ctx, []models.ServerCatalog{
+		{
+			Model:     "Mixed",
+			Disks:     []models.ServerDisk{{HDDCount: 2, HDDSize: 120, HDDType: 3}, {HDDCount: 4, HDDSize: 2048, HDDType: 1}},
+			VersionID: versionID,
+		},
+		{
+			Model:     "Single",
+			Disks:     []models.ServerDisk{{HDDCount: 4, HDDSize: 1024, HDDType: 1}},
+			VersionID: versionID,
+		},
+	}))
+
+	tests := []struct {
+		name     string
+		ctr      *dto.ListServersCtr
+		expected []string
+	}{
+		{
+			name:     "storage sums every disk group",
+			ctr:      &dto.ListServersCtr{StorageMin: &[]int{8000}[0]},
+			expected: []string{"Mixed"},
+		},
+		{
+			name:     "storage below the summed capacity",
+			ctr:      &dto.ListServersCtr{StorageMax: &[]int{8192}[0]},
+			expected: []string{"Single"},
+		},
+		{
+			name:     "HDD type of any disk group",
+			ctr:      &dto.ListServersCtr{HDD: &[]int{3}[0]},
+			expected: []string{"Mixed"},
+		},
+		{
+			name:     "HDD type shared by both servers",
+			ctr:      &dto.ListServersCtr{HDD: &[]int{1}[0]},
+			expected: []string{"Mixed", "Single"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.ctr.Page = &utils.Page{Limit: 10, Current: 1}
+			servers, err := repo.GetServers(ctx, tt.ctr)
+			assert.NoError(t, err)
+
+			names := []string{}
+			for _, server := range servers {
+				names = append(names, server.Model)
+				assert.NotEmpty(t, server.Disks)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestServerCatalog_GetServers_RAM(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	versionID := createActiveVersion(t, db)
+	assert.NoError(t, repo.Upload(ctx, []models.ServerCatalog{
+		{Model: "DDR4", RamSize: 64, RamType: 2, VersionID: versionID},
+		{Model: "DDR4 ECC", RamSize: 64, RamType: 2, RamECC: true, VersionID: versionID},
+		{Model: "DDR5 ECC", RamSize: 128, RamType: 3, RamECC: true, RamSpeed: 4800, VersionID: versionID},
+	}))
+
+	tests := []struct {
+		name     string
+		ctr      *dto.ListServersCtr
+		expected []string
+	}{
+		{
+			name:     "filter by RAM type",
+			ctr:      &dto.ListServersCtr{RAMType: &[]int{2}[0]},
+			expected: []string{"DDR4", "DDR4 ECC"},
+		},
+		{
+			name:     "ECC memory only",
+			ctr:      &dto.ListServersCtr{ECC: &[]bool{true}[0]},
+			expected: []string{"DDR4 ECC", "DDR5 ECC"},
+		},
+		{
+			name:     "without ECC memory",
+			ctr:      &dto.ListServersCtr{ECC: &[]bool{false}[0]},
+			expected: []string{"DDR4"},
+		},
+		{
+			name:     "RAM type and ECC",
+			ctr:      &dto.ListServersCtr{RAMType: &[]int{2}[0], ECC: &[]bool{true}[0]},
+			expected: []string{"DDR4 ECC"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.ctr.Page = &utils.Page{Limit: 10, Current: 1}
+			servers, err := repo.GetServers(ctx, tt.ctr)
+			assert.NoError(t, err)
+
+			names := []string{}
+			for _, server := range servers {
+				names = append(names, server.Model)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestServerCatalog_GetServers_Price(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	versionID := createActiveVersion(t, db)
+	assert.NoError(t, repo.Upload(ctx, []models.ServerCatalog{
+		{Model: "Cheap EUR", RamSize: 16, Price: 49.99, Currency: utils.CurrencyEuro, VersionID: versionID},
+		{Model: "Pricey EUR", RamSize: 32, Price: 149.99, Currency: utils.CurrencyEuro, VersionID: versionID},
+		{Model: "Cheap USD", RamSize: 16, Price: 59.99, Currency: utils.CurrencyUSD, VersionID: versionID},
+		{Model: "Boundary EUR", RamSize: 16, Price: 100, Currency: utils.CurrencyEuro, VersionID: versionID},
+	}))
+
+	tests := []struct {
+		name     string
+		ctr      *dto.ListServersCtr
+		expected []string
+	}{
+		{
+			name:     "currency only",
+			ctr:      &dto.ListServersCtr{Currency: &[]int{utils.CurrencyUSD}[0]},
+			expected: []string{"Cheap USD"},
+		},
+		{
+			name:     "upper bound within the currency is inclusive",
+			ctr:      &dto.ListServersCtr{Currency: &[]int{utils.CurrencyEuro}[0], MaxPrice: &[]float64{100}[0]},
+			expected: []string{"Cheap EUR", "Boundary EUR"},
+		},
+		{
+			name:     "lower bound within the currency",
+			ctr:      &dto.ListServersCtr{Currency: &[]int{utils.CurrencyEuro}[0], MinPrice: &[]float64{100}[0]},
+			expected: []string{"Pricey EUR", "Boundary EUR"},
+		},
+		{
+			name: "range combined with RAM",
+			ctr: &dto.ListServersCtr{Currency: &[]int{utils.CurrencyEuro}[0], RAM: []int{16},
+				MinPrice: &[]float64{40}[0], MaxPrice: &[]float64{60}[0]},
+			expected: []string{"Cheap EUR"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.ctr.Page = &utils.Page{Limit: 10, Current: 1}
+			servers, err := repo.GetServers(ctx, tt.ctr)
+			assert.NoError(t, err)
+
+			names := []string{}
+			for _, server := range servers {
+				names = append(names, server.Model)
+			}
+			assert.Equal(t, tt.expected, names)
+			assert.Equal(t, len(tt.expected), tt.ctr.Page.Total)
+		})
+	}
+}
+
+func TestServerCatalog_GetServers_Sort(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	versionID := createActiveVersion(t, db)
+	assert.NoError(t, repo.Upload(ctx, []models.ServerCatalog{
+		{Model: "B", RamSize: 32, Location: "FrankfurtFRA-10", Price: 99.99, VersionID: versionID,
+			Disks: []models.ServerDisk{{HDDCount: 2, HDDSize: 1024, HDDType: 1}}},
+		{Model: "A", RamSize: 16, Location: "AmsterdamAMS-01", Price: 49.99, VersionID: versionID,
+			Disks: []models.ServerDisk{{HDDCount: 1, HDDSize: 480, HDDType: 3}, {HDDCount: 2, HDDSize: 2048, HDDType: 1}}},
+		{Model: "C", RamSize: 32, Location: "AmsterdamAMS-01", Price: 49.99, VersionID: versionID,
+			Disks: []models.ServerDisk{{HDDCount: 4, HDDSize: 120, HDDType: 3}}},
+	}))
+
+	tests := []struct {
+		name     string
+		sort     []utils.SortKey
+		expected []string
+	}{
+		{
+			name:     "by ID without sort keys",
+			expected: []string{"B", "A", "C"},
+		},
+		{
+			name:     "price ties ordered by ID",
+			sort:     []utils.SortKey{{Key: utils.SortPrice}},
+			expected: []string{"A", "C", "B"},
+		},
+		{
+			name:     "descending RAM then price",
+			sort:     []utils.SortKey{{Key: utils.SortRAM, Desc: true}, {Key: utils.SortPrice}},
+			expected: []string{"C", "B", "A"},
+		},
+		{
+			name:     "storage sums every disk group",
+			sort:     []utils.SortKey{{Key: utils.SortStorage, Desc: true}},
+			expected: []string{"A", "B", "C"},
+		},
+		{
+			name:     "model",
+			sort:     []utils.SortKey{{Key: utils.SortModel}},
+			expected: []string{"A", "B", "C"},
+		},
+		{
+			name:     "location then descending model",
+			sort:     []utils.SortKey{{Key: utils.SortLocation}, {Key: utils.SortModel, Desc: true}},
+			expected: []string{"C", "A", "B"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			servers, err := repo.GetServers(ctx, &dto.ListServersCtr{
+				Sort: tt.sort,
+				Page: &utils.Page{Limit: 10, Current: 1},
+			})
+			assert.NoError(t, err)
+
+			names := []string{}
+			for _, server := range servers {
+				names = append(names, server.Model)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+
+	// pages never overlap when every server shares the sort key
+	seen := []string{}
+	for page := 1; page <= 3; page++ {
+		servers, err := repo.GetServers(ctx, &dto.ListServersCtr{
+			Sort: []utils.SortKey{{Key: utils.SortRAM}},
+			Page: &utils.Page{Limit: 1, Current: page},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, servers, 1)
+		seen = append(seen, servers[0].Model)
+	}
+	assert.Equal(t, []string{"A", "B", "C"}, seen)
+
+	_, err := repo.GetServers(ctx, &dto.ListServersCtr{
+		Sort: []utils.SortKey{{Key: "cpu"}},
+		Page: &utils.Page{Limit: 10, Current: 1},
+	})
+	assert.ErrorContains(t, err, "unknown sort key")
 }
 
 func TestServerCatalog_GetServers_Error(t *testing.T) {
 	db := setupTestDB(t)
-	_ = NewServerCatalog(db)
+	_ = NewServerCatalog(db, 0)
 	ctx := context.Background()
 
 	invalidDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
@@ -221,7 +717,7 @@ func TestServerCatalog_GetServers_Error(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	invalidRepo := NewServerCatalog(invalidDB)
+	invalidRepo := NewServerCatalog(invalidDB, 0)
 
 	// Test with invalid table name to trigger error
 	invalidDB.Exec("DROP TABLE IF EXISTS server_catalogs")
@@ -235,3 +731,42 @@ func TestServerCatalog_GetServers_Error(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to fetch count of servers")
 }
+
+func TestServerCatalog_StreamServers(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	versionID := createActiveVersion(t, db)
+	servers := []models.ServerCatalog{}
+	for _, model := range []string{"A", "B", "C", "D", "E"} {
+		servers = append(servers, models.ServerCatalog{
+			Model:     model,
+			RamSize:   16,
+			Disks:     []models.ServerDisk{{HDDCount: 2, HDDSize: 120, HDDType: 3}},
+			VersionID: versionID,
+		})
+	}
+	servers[3].RamSize = 32
+	assert.NoError(t, repo.Upload(ctx, servers))
+
+	var batches []int
+	names := []string{}
+	err := repo.StreamServers(ctx, &dto.ListServersCtr{RAM: []int{16}}, 2, func(batch []models.ServerCatalog) error {
+		batches = append(batches, len(batch))
+		for _, server := range batch {
+			names = append(names, server.Model)
+			assert.NotEmpty(t, server.Disks)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 2}, batches)
+	assert.Equal(t, []string{"A", "B", "C", "E"}, names)
+
+	err = repo.StreamServers(ctx, &dto.ListServersCtr{}, 2, func(batch []models.ServerCatalog) error {
+		return errors.New("client went away")
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to stream servers")
+}
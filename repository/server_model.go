@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/server-catalog/models"
+	"strings"
+)
+
+// SaveModels stores the split model strings. Known names get their parts updated
+// since the split rules may have changed. Names are matched case-insensitively like
+// the unique index does. The IDs of the models are set on return.
+func (sc *ServerCatalog) SaveModels(ctx context.Context, serverModels []*models.ServerModel) error {
+	if len(serverModels) < 1 {
+		return nil
+	}
+
+	names := make([]string, 0, len(serverModels))
+	for _, serverModel := range serverModels {
+		names = append(names, serverModel.Name)
+	}
+
+	existing := []models.ServerModel{}
+	if err := sc.db.WithContext(ctx).Where("name IN ?", names).Find(&existing).Error; err != nil {
+		return fmt.Errorf("repository:server_model:: failed to fetch models %v", err)
+	}
+	known := make(map[string]models.ServerModel, len(existing))
+	for _, serverModel := range existing {
+		known[nameKey(serverModel.Name)] = serverModel
+	}
+
+	inserts := make([]*models.ServerModel, 0)
+	// names differing only in case are stored once, the later ones share the ID
+	pending := make(map[string]*models.ServerModel)
+	duplicates := make([]*models.ServerModel, 0)
+	for _, serverModel := range serverModels {
+		key := nameKey(serverModel.Name)
+		current, ok := known[key]
+		if !ok {
+			if _, ok := pending[key]; ok {
+				duplicates = append(duplicates, serverModel)
+				continue
+			}
+			pending[key] = serverModel
+			inserts = append(inserts, serverModel)
+			continue
+		}
+
+		serverModel.ID = current.ID
+		if serverModel.Vendor == current.Vendor && serverModel.Chassis == current.Chassis && serverModel.CPU == current.CPU {
+			continue
+		}
+		err := sc.db.WithContext(ctx).Model(serverModel).
+			Updates(map[string]interface{}{"vendor": serverModel.Vendor, "chassis": serverModel.Chassis, "cpu": serverModel.CPU}).Error
+		if err != nil {
+			return fmt.Errorf("repository:server_model:: failed to update model %v", err)
+		}
+	}
+
+	if len(inserts) > 0 {
+		if err := sc.db.WithContext(ctx).Create(inserts).Error; err != nil {
+			return fmt.Errorf("repository:server_model:: failed to insert models %v", err)
+		}
+	}
+	for _, serverModel := range duplicates {
+		serverModel.ID = pending[nameKey(serverModel.Name)].ID
+	}
+	return nil
+}
+
+// nameKey folds the case of a unique name, the unique indexes of the model and
+// location names use the case-insensitive collation of the database
+func nameKey(name string) string {
+	return strings.ToLower(name)
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerCatalog_SaveModels(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	hp := &models.ServerModel{Name: "HP DL120G7Intel G850", Chassis: "HP DL120G7", CPU: "Intel G850"}
+	assert.NoError(t, repo.SaveModels(ctx, []*models.ServerModel{hp}))
+	assert.NotZero(t, hp.ID)
+
+	// a known model gets the parts of the current rules
+	updated := &models.ServerModel{Name: "HP DL120G7Intel G850", Vendor: "HP", Chassis: "DL120G7", CPU: "Intel G850"}
+	dell := &models.ServerModel{Name: "Dell R210Intel Xeon X3440", Vendor: "Dell", Chassis: "R210", CPU: "Intel Xeon X3440"}
+	assert.NoError(t, repo.SaveModels(ctx, []*models.ServerModel{updated, dell}))
+	assert.Equal(t, hp.ID, updated.ID)
+	assert.NotZero(t, dell.ID)
+	assert.NotEqual(t, hp.ID, dell.ID)
+
+	var stored []models.ServerModel
+	db.Order("id").Find(&stored)
+	assert.Equal(t, []models.ServerModel{*updated, *dell}, stored)
+}
+
+func TestServerCatalog_SaveModels_Case(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	// the unique index of the name ignores the case
+	dell := &models.ServerModel{Name: "Dell R210Intel Xeon X3440", Vendor: "Dell", Chassis: "R210", CPU: "Intel Xeon X3440"}
+	upper := &models.ServerModel{Name: "DELL R210Intel Xeon X3440", Vendor: "Dell", Chassis: "R210", CPU: "Intel Xeon X3440"}
+	assert.NoError(t, repo.SaveModels(ctx, []*models.ServerModel{dell, upper}))
+	assert.NotZero(t, dell.ID)
+	assert.Equal(t, dell.ID, upper.ID)
+
+	var count int64
+	db.Model(&models.ServerModel{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestServerCatalog_GetServers_Models(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	hp := &models.ServerModel{Name: "HP DL120G7Intel G850", Vendor: "HP", Chassis: "DL120G7", CPU: "Intel G850"}
+	dell := &models.ServerModel{Name: "Dell R730XD2x Intel Xeon E5-2650v4", Vendor: "Dell", Chassis: "R730XD", CPU: "2x Intel Xeon E5-2650v4"}
+	assert.NoError(t, repo.SaveModels(ctx, []*models.ServerModel{hp, dell}))
+
+	versionID := createActiveVersion(t, db)
+	assert.NoError(t, repo.Upload(ctx, []models.ServerCatalog{
+		{Model: hp.Name, ModelID: hp.ID, VersionID: versionID},
+		{Model: dell.Name, ModelID: dell.ID, VersionID: versionID},
+	}))
+
+	tests := []struct {
+		name     string
+		ctr      *dto.ListServersCtr
+		expected []string
+	}{
+		{
+			name:     "filter by vendor",
+			ctr:      &dto.ListServersCtr{Vendor: &[]string{"Dell"}[0]},
+			expected: []string{dell.Name},
+		},
+		{
+			name:     "filter by part of the CPU",
+			ctr:      &dto.ListServersCtr{CPU: &[]string{"G850"}[0]},
+			expected: []string{hp.Name},
+		},
+		{
+			name:     "vendor and CPU of different servers",
+			ctr:      &dto.ListServersCtr{Vendor: &[]string{"HP"}[0], CPU: &[]string{"E5-2650"}[0]},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.ctr.Page = &utils.Page{Limit: 10, Current: 1}
+			servers, err := repo.GetServers(ctx, tt.ctr)
+			assert.NoError(t, err)
+
+			names := []string{}
+			for _, server := range servers {
+				names = append(names, server.Model)
+				// the parts are loaded with the servers
+				assert.NotNil(t, server.ServerModel)
+				assert.Equal(t, server.Model, server.ServerModel.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"gorm.io/gorm"
+)
+
+func (sc *ServerCatalog) CreateUpload(ctx context.Context, upload *models.Upload) error {
+	if err := sc.db.WithContext(ctx).Create(upload).Error; err != nil {
+		return fmt.Errorf("repository:upload:: failed to create upload %v", err)
+	}
+	return nil
+}
+
+func (sc *ServerCatalog) UpdateUpload(ctx context.Context, upload *models.Upload) error {
+	if err := sc.db.WithContext(ctx).Save(upload).Error; err != nil {
+		return fmt.Errorf("repository:upload:: failed to update upload %v", err)
+	}
+	return nil
+}
+
+// GetUploads returns a page of the upload history, newest first
+func (sc *ServerCatalog) GetUploads(ctx context.Context, page *utils.Page) ([]models.Upload, error) {
+	var tb models.Upload
+	uploads := []models.Upload{}
+	qry := sc.db.WithContext(ctx).Model(&tb)
+
+	var count int64
+	if err := qry.Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("repository:upload:: failed to fetch count of uploads %v", err)
+	}
+	page.Total = int(count)
+
+	err := qry.Order("id DESC").Offset(page.Offset()).Limit(page.Limit).Find(&uploads).Error
+	if err != nil {
+		return nil, fmt.Errorf("repository:upload:: failed to fetch uploads %v", err)
+	}
+	return uploads, nil
+}
+
+// GetLastSuccessfulUpload returns the latest upload that stored a catalog version,
+// utils.ErrUploadNotFound is returned while there is none
+func (sc *ServerCatalog) GetLastSuccessfulUpload(ctx context.Context) (*models.Upload, error) {
+	upload := &models.Upload{}
+	err := sc.db.WithContext(ctx).Where("outcome = ?", models.UploadOutcomeSucceeded).Order("id DESC").First(upload).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("repository:upload:: failed to fetch last upload %v", err)
+	}
+	return upload, nil
+}
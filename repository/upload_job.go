@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"gorm.io/gorm"
+)
+
+type UploadJob struct {
+	db *gorm.DB
+}
+
+func NewUploadJob(db *gorm.DB) JobRepository {
+	return &UploadJob{db: db}
+}
+
+func (uj *UploadJob) CreateJob(ctx context.Context, job *models.UploadJob) error {
+	if err := uj.db.WithContext(ctx).Create(job).Error; err != nil {
+		return fmt.Errorf("repository:upload_job:: failed to create job %v", err)
+	}
+	return nil
+}
+
+func (uj *UploadJob) UpdateJob(ctx context.Context, job *models.UploadJob) error {
+	if err := uj.db.WithContext(ctx).Save(job).Error; err != nil {
+		return fmt.Errorf("repository:upload_job:: failed to update job %v", err)
+	}
+	return nil
+}
+
+func (uj *UploadJob) GetJob(ctx context.Context, id uint) (*models.UploadJob, error) {
+	job := &models.UploadJob{}
+	err := uj.db.WithContext(ctx).First(job, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("repository:upload_job:: failed to fetch job %v", err)
+	}
+	return job, nil
+}
+
+// FailUnfinishedJobs marks every job that has not reached a terminal state as failed.
+// It is used on startup since jobs never survive a restart of the process.
+func (uj *UploadJob) FailUnfinishedJobs(ctx context.Context, reason string) (int64, error) {
+	var tb models.UploadJob
+	res := uj.db.WithContext(ctx).Model(&tb).
+		Where("state NOT IN ?", []string{models.JobStateDone, models.JobStateFailed}).
+		Updates(map[string]interface{}{"state": models.JobStateFailed, "error": reason})
+	if res.Error != nil {
+		return 0, fmt.Errorf("repository:upload_job:: failed to fail unfinished jobs %v", res.Error)
+	}
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadJob_CreateUpdateGet(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUploadJob(db)
+	ctx := context.Background()
+
+	job := &models.UploadJob{State: models.JobStateQueued}
+	err := repo.CreateJob(ctx, job)
+	assert.NoError(t, err)
+	assert.NotZero(t, job.ID)
+	assert.False(t, job.CreatedAt.IsZero())
+
+	job.State = models.JobStateInserting
+	job.RowsProcessed = 500
+	job.Result = `{"version":7}`
+	err = repo.UpdateJob(ctx, job)
+	assert.NoError(t, err)
+
+	result, err := repo.GetJob(ctx, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.JobStateInserting, result.State)
+	assert.Equal(t, 500, result.RowsProcessed)
+	assert.Equal(t, `{"version":7}`, result.Result)
+}
+
+func TestUploadJob_GetJob_NotFound(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUploadJob(db)
+
+	_, err := repo.GetJob(context.Background(), 42)
+	assert.ErrorIs(t, err, utils.ErrJobNotFound)
+}
+
+func TestUploadJob_FailUnfinishedJobs(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUploadJob(db)
+	ctx := context.Background()
+
+	testData := []models.UploadJob{
+		{State: models.JobStateQueued},
+		{State: models.JobStateParsing},
+		{State: models.JobStateInserting},
+		{State: models.JobStateDone},
+		{State: models.JobStateFailed, Error: "invalid catalog"},
+	}
+	db.Create(&testData)
+
+	n, err := repo.FailUnfinishedJobs(ctx, "interrupted")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	for _, job := range testData {
+		result, err := repo.GetJob(ctx, job.ID)
+		assert.NoError(t, err)
+		if job.Finished() {
+			assert.Equal(t, job.State, result.State)
+			assert.Equal(t, job.Error, result.Error)
+			continue
+		}
+		assert.Equal(t, models.JobStateFailed, result.State)
+		assert.Equal(t, "interrupted", result.Error)
+	}
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerCatalog_CreateUpdateUpload(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	upload := &models.Upload{
+		Source:    models.UploadSourceFile,
+		Filename:  "servers.xlsx",
+		Size:      20480,
+		Checksum:  "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		Uploader:  "app-key:1a2b3c4d5e6f",
+		Mode:      "append",
+		Outcome:   models.UploadOutcomeRunning,
+		StartedAt: time.Now(),
+	}
+	err := repo.CreateUpload(ctx, upload)
+	assert.NoError(t, err)
+	assert.NotZero(t, upload.ID)
+
+	version := createActiveVersion(t, db)
+	upload.Outcome = models.UploadOutcomeSucceeded
+	upload.Rows, upload.Inserted = 486, 486
+	upload.VersionID = &version
+	err = repo.UpdateUpload(ctx, upload)
+	assert.NoError(t, err)
+
+	result, err := repo.GetLastSuccessfulUpload(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, upload.ID, result.ID)
+	assert.Equal(t, "servers.xlsx", result.Filename)
+	assert.Equal(t, 486, result.Rows)
+	assert.Equal(t, &version, result.VersionID)
+}
+
+func TestServerCatalog_GetLastSuccessfulUpload(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	_, err := repo.GetLastSuccessfulUpload(ctx)
+	assert.ErrorIs(t, err, utils.ErrUploadNotFound)
+
+	testData := []models.Upload{
+		{Source: models.UploadSourceFile, Checksum: "a", Mode: "append", Outcome: models.UploadOutcomeSucceeded, StartedAt: time.Now()},
+		{Source: models.UploadSourceFile, Checksum: "b", Mode: "append", Outcome: models.UploadOutcomeSucceeded, StartedAt: time.Now()},
+		{Source: models.UploadSourceFile, Checksum: "c", Mode: "append", Outcome: models.UploadOutcomeFailed, StartedAt: time.Now()},
+		{Source: models.UploadSourceFile, Checksum: "b", Mode: "append", Outcome: models.UploadOutcomeDuplicate, StartedAt: time.Now()},
+	}
+	db.Create(&testData)
+
+	result, err := repo.GetLastSuccessfulUpload(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, testData[1].ID, result.ID)
+}
+
+func TestServerCatalog_GetUploads(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServerCatalog(db, 0)
+	ctx := context.Background()
+
+	testData := []models.Upload{
+		{Source: models.UploadSourceFile, Checksum: "a", Mode: "append", Outcome: models.UploadOutcomeSucceeded, StartedAt: time.Now()},
+		{Source: models.UploadSourceBulk, Checksum: "b", Mode: "upsert", Outcome: models.UploadOutcomeFailed, StartedAt: time.Now()},
+		{Source: models.UploadSourceFile, Checksum: "c", Mode: "replace", Outcome: models.UploadOutcomeSucceeded, StartedAt: time.Now()},
+	}
+	db.Create(&testData)
+
+	page := &utils.Page{Limit: 2, Current: 1}
+	result, err := repo.GetUploads(ctx, page)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, page.Total)
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, testData[2].ID, result[0].ID)
+		assert.Equal(t, testData[1].ID, result[1].ID)
+	}
+
+	page = &utils.Page{Limit: 2, Current: 2}
+	result, err = repo.GetUploads(ctx, page)
+	assert.NoError(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, testData[0].ID, result[0].ID)
+	}
+}
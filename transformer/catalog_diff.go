@@ -0,0 +1,108 @@
+package transformer
+
+import (
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/lookup"
+	"github.com/server-catalog/models"
+	"github.com/xuri/excelize/v2"
+	"math"
+)
+
+// TransformPriceChange describes the price change between two versions of the same server
+func TransformPriceChange(old, new models.ServerCatalog, lookups *lookup.Registry) dto.PriceChangeResp {
+	servers := TransformServerList([]models.ServerCatalog{old, new}, lookups)
+	change := dto.PriceChangeResp{
+		Model:    servers[1].Model,
+		Ram:      servers[1].Ram,
+		HDD:      servers[1].HDD,
+		Location: servers[1].Location,
+		OldPrice: servers[0].Price,
+		NewPrice: servers[1].Price,
+	}
+
+	// prices in different currencies can't be compared
+	if old.Currency == new.Currency && old.Price != 0 {
+		delta := math.Round((new.Price-old.Price)/old.Price*10000) / 100
+		change.DeltaPercent = &delta
+	}
+	return change
+}
+
+// TransformSpecChange describes the configuration change of a server at the same model and location
+func TransformSpecChange(old, new models.ServerCatalog, lookups *lookup.Registry) dto.SpecChangeResp {
+	servers := TransformServerList([]models.ServerCatalog{old, new}, lookups)
+	return dto.SpecChangeResp{
+		Model:    servers[1].Model,
+		Location: servers[1].Location,
+		OldRam:   servers[0].Ram,
+		NewRam:   servers[1].Ram,
+		OldHDD:   servers[0].HDD,
+		NewHDD:   servers[1].HDD,
+		OldPrice: servers[0].Price,
+		NewPrice: servers[1].Price,
+	}
+}
+
+// DiffReport builds a workbook with one sheet per kind of change of a catalog diff
+func DiffReport(diff *dto.CatalogDiffResp) (*excelize.File, error) {
+	f := excelize.NewFile()
+
+	if err := writeSheet(f, "Added", serverRows(diff.Added)); err != nil {
+		return nil, err
+	}
+	if err := writeSheet(f, "Removed", serverRows(diff.Removed)); err != nil {
+		return nil, err
+	}
+
+	rows := [][]interface{}{{"Model", "RAM", "HDD", "Location", "Old Price", "New Price", "Delta %"}}
+	for _, change := range diff.PriceChanges {
+		var delta interface{}
+		if change.DeltaPercent != nil {
+			delta = *change.DeltaPercent
+		}
+		rows = append(rows, []interface{}{change.Model, change.Ram, change.HDD, change.Location,
+			change.OldPrice, change.NewPrice, delta})
+	}
+	if err := writeSheet(f, "Price changes", rows); err != nil {
+		return nil, err
+	}
+
+	rows = [][]interface{}{{"Model", "Location", "Old RAM", "New RAM", "Old HDD", "New HDD", "Old Price", "New Price"}}
+	for _, change := range diff.SpecChanges {
+		rows = append(rows, []interface{}{change.Model, change.Location, change.OldRam, change.NewRam,
+			change.OldHDD, change.NewHDD, change.OldPrice, change.NewPrice})
+	}
+	if err := writeSheet(f, "Spec changes", rows); err != nil {
+		return nil, err
+	}
+
+	// the default sheet of a new workbook is replaced by the ones above
+	if err := f.DeleteSheet("Sheet1"); err != nil {
+		return nil, err
+	}
+	f.SetActiveSheet(0)
+	return f, nil
+}
+
+// serverRows lays out servers under the catalog header
+func serverRows(servers []dto.ListServerResp) [][]interface{} {
+	rows := [][]interface{}{CatalogHeader}
+	for _, server := range servers {
+		rows = append(rows, []interface{}{server.Model, server.Ram, server.HDD, server.Location, server.Price})
+	}
+	return rows
+}
+
+// writeSheet creates a sheet and fills it with rows starting at A1
+func writeSheet(f *excelize.File, name string, rows [][]interface{}) error {
+	if _, err := f.NewSheet(name); err != nil {
+		return err
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow(name, cell, &row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package transformer
+
+import (
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/lookup"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTransformPriceChange(t *testing.T) {
+	old := models.ServerCatalog{
+		Model:    "Dell R210",
+		RamSize:  16,
+		RamType:  utils.RAMTypeDDR3,
+		Disks:    []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: utils.HDDTypeSATA2}},
+		Location: "AmsterdamAMS-01",
+		Price:    40,
+		Currency: utils.CurrencyEuro,
+	}
+
+	repriced := old
+	repriced.Price = 35
+	delta := -12.5
+	assert.Equal(t, dto.PriceChangeResp{
+		Model:        "Dell R210",
+		Ram:          "16GBDDR3",
+		HDD:          "2x500GBSATA2",
+		Location:     "AmsterdamAMS-01",
+		OldPrice:     "€40.00",
+		NewPrice:     "€35.00",
+		DeltaPercent: &delta,
+	}, TransformPriceChange(old, repriced, lookup.Static(lookup.Seed)))
+
+	// no delta across currencies
+	converted := old
+	converted.Currency = utils.CurrencyUSD
+	change := TransformPriceChange(old, converted, lookup.Static(lookup.Seed))
+	assert.Equal(t, "$40.00", change.NewPrice)
+	assert.Nil(t, change.DeltaPercent)
+}
+
+func TestDiffReport(t *testing.T) {
+	delta := 10.0
+	f, err := DiffReport(&dto.CatalogDiffResp{
+		Added: []dto.ListServerResp{
+			{Model: "Dell R210", Ram: "16GBDDR3", HDD: "2x500GBSATA2", Location: "AmsterdamAMS-01", Price: "€99.99"},
+		},
+		Removed: []dto.ListServerResp{},
+		PriceChanges: []dto.PriceChangeResp{
+			{Model: "HP DL120G7", Ram: "4GBDDR3", HDD: "4x1TBSATA2", Location: "AmsterdamAMS-01",
+				OldPrice: "€40.00", NewPrice: "€44.00", DeltaPercent: &delta},
+		},
+		SpecChanges: []dto.SpecChangeResp{
+			{Model: "HP DL120G7", Location: "FrankfurtFRA-10", OldRam: "4GBDDR3", NewRam: "8GBDDR3",
+				OldHDD: "4x1TBSATA2", NewHDD: "4x1TBSATA2", OldPrice: "€40.00", NewPrice: "€40.00"},
+		},
+	})
+	assert.NoError(t, err)
+	defer f.Close()
+
+	assert.Equal(t, []string{"Added", "Removed", "Price changes", "Spec changes"}, f.GetSheetList())
+
+	rows, err := f.GetRows("Added")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Model", "RAM", "HDD", "Location", "Price"},
+		{"Dell R210", "16GBDDR3", "2x500GBSATA2", "AmsterdamAMS-01", "€99.99"},
+	}, rows)
+
+	rows, err = f.GetRows("Price changes")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"HP DL120G7", "4GBDDR3", "4x1TBSATA2", "AmsterdamAMS-01", "€40.00", "€44.00", "10"}, rows[1])
+
+	rows, err = f.GetRows("Spec changes")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"HP DL120G7", "FrankfurtFRA-10", "4GBDDR3", "8GBDDR3", "4x1TBSATA2", "4x1TBSATA2", "€40.00", "€40.00"}, rows[1])
+}
@@ -0,0 +1,137 @@
+package transformer
+
+import (
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/lookup"
+	"github.com/server-catalog/models"
+	"github.com/xuri/excelize/v2"
+	"strings"
+)
+
+// CatalogHeader is the header row of an uploaded catalog
+var CatalogHeader = []interface{}{"Model", "RAM", "HDD", "Location", "Price"}
+
+// exportSheet is the sheet the servers are exported to
+const exportSheet = "Sheet1"
+
+// ServerListReport builds a workbook listing servers as rendered by the API under the
+// catalog header, prices are kept as formatted text
+func ServerListReport(servers []dto.ListServerResp) (*excelize.File, error) {
+	f := excelize.NewFile()
+	if err := writeSheet(f, exportSheet, serverRows(servers)); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// CatalogExport streams servers into a workbook laid out like an upload, so the
+// exported file can be edited and uploaded again. Prices are numeric cells whose
+// number format carries the currency.
+type CatalogExport struct {
+	file    *excelize.File
+	stream  *excelize.StreamWriter
+	lookups *lookup.Registry
+	// styles holds the price style of every currency seen so far
+	styles map[int]int
+	row    int
+}
+
+// NewCatalogExport starts a workbook holding the catalog header
+func NewCatalogExport(lookups *lookup.Registry) (*CatalogExport, error) {
+	f := excelize.NewFile()
+	sw, err := f.NewStreamWriter(exportSheet)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	ce := &CatalogExport{file: f, stream: sw, lookups: lookups, styles: make(map[int]int), row: 1}
+	for col, width := range []float64{40, 22, 28, 22, 14} {
+		if err := sw.SetColWidth(col+1, col+1, width); err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+	}
+	if err := sw.SetRow("A1", CatalogHeader); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return ce, nil
+}
+
+// Add appends a row for every server
+func (ce *CatalogExport) Add(servers []models.ServerCatalog) error {
+	for _, server := range servers {
+		style, err := ce.priceStyle(server.Currency)
+		if err != nil {
+			return err
+		}
+
+		ce.row++
+		cell, _ := excelize.CoordinatesToCellName(1, ce.row)
+		err = ce.stream.SetRow(cell, []interface{}{
+			server.Model,
+			TransformRAM(server, ce.lookups),
+			TransformDisks(server.Disks, ce.lookups),
+			server.Location,
+			excelize.Cell{StyleID: style, Value: server.Price},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Finish completes the workbook, the caller closes the returned file once written
+func (ce *CatalogExport) Finish() (*excelize.File, error) {
+	if err := ce.stream.Flush(); err != nil {
+		_ = ce.file.Close()
+		return nil, err
+	}
+	return ce.file, nil
+}
+
+// Close discards an unfinished workbook
+func (ce *CatalogExport) Close() error {
+	return ce.file.Close()
+}
+
+// priceStyle returns the style rendering amounts in the given currency, the default
+// style when the currency is unknown
+func (ce *CatalogExport) priceStyle(currencyID int) (int, error) {
+	if style, ok := ce.styles[currencyID]; ok {
+		return style, nil
+	}
+
+	currency, ok := ce.lookups.Currency(currencyID)
+	if !ok {
+		return 0, nil
+	}
+	numFmt := priceNumberFormat(currency)
+	style, err := ce.file.NewStyle(&excelize.Style{CustomNumFmt: &numFmt})
+	if err != nil {
+		return 0, err
+	}
+	ce.styles[currencyID] = style
+	return style, nil
+}
+
+// priceNumberFormat returns the spreadsheet number format of amounts in a currency,
+// e.g. "€"#,##0.00 or 0.00" kr"
+func priceNumberFormat(currency models.Currency) string {
+	number := "0"
+	if currency.ThousandsSeparator != "" {
+		number = "#,##0"
+	}
+	if currency.Decimals > 0 {
+		number += "." + strings.Repeat("0", currency.Decimals)
+	}
+
+	symbol := strings.ReplaceAll(currency.Symbol, `"`, `\"`)
+	if currency.SymbolPosition == models.SymbolAfter {
+		return number + `" ` + symbol + `"`
+	}
+	return `"` + symbol + `"` + number
+}
@@ -0,0 +1,90 @@
+package transformer
+
+import (
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/lookup"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"testing"
+)
+
+func TestPriceNumberFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency models.Currency
+		expected string
+	}{
+		{
+			name:     "symbol before",
+			currency: models.Currency{Symbol: "€", SymbolPosition: models.SymbolBefore, Decimals: 2},
+			expected: `"€"0.00`,
+		},
+		{
+			name:     "symbol after with grouping",
+			currency: models.Currency{Symbol: "kr", SymbolPosition: models.SymbolAfter, Decimals: 2, ThousandsSeparator: " "},
+			expected: `#,##0.00" kr"`,
+		},
+		{
+			name:     "no decimals",
+			currency: models.Currency{Symbol: "¥", SymbolPosition: models.SymbolBefore, ThousandsSeparator: ","},
+			expected: `"¥"#,##0`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, priceNumberFormat(tt.currency))
+		})
+	}
+}
+
+func TestCatalogExport(t *testing.T) {
+	export, err := NewCatalogExport(lookup.Static(lookup.Seed))
+	assert.NoError(t, err)
+
+	err = export.Add([]models.ServerCatalog{
+		{
+			Model:    "Dell R210",
+			RamSize:  16,
+			RamType:  utils.RAMTypeDDR3,
+			Disks:    []models.ServerDisk{{HDDCount: 8, HDDSize: 1536, HDDType: utils.HDDTypeSAS}},
+			Location: "AmsterdamAMS-01",
+			Price:    99.5,
+			Currency: utils.CurrencyEuro,
+		},
+	})
+	assert.NoError(t, err)
+
+	f, err := export.Finish()
+	assert.NoError(t, err)
+	defer f.Close()
+
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Model", "RAM", "HDD", "Location", "Price"},
+		{"Dell R210", "16GBDDR3", "8x1536GBSAS", "AmsterdamAMS-01", "€99.50"},
+	}, rows)
+
+	raw, err := f.GetCellValue("Sheet1", "E2", excelize.Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "99.5", raw)
+}
+
+func TestServerListReport(t *testing.T) {
+	f, err := ServerListReport([]dto.ListServerResp{
+		{Model: "Dell R210", Ram: "16GBDDR3", HDD: "2x500GBSATA2", Location: "AmsterdamAMS-01", Price: "€99.99"},
+	})
+	assert.NoError(t, err)
+	defer f.Close()
+
+	assert.Equal(t, []string{"Sheet1"}, f.GetSheetList())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Model", "RAM", "HDD", "Location", "Price"},
+		{"Dell R210", "16GBDDR3", "2x500GBSATA2", "AmsterdamAMS-01", "€99.99"},
+	}, rows)
+}
@@ -0,0 +1,45 @@
+package transformer
+
+import (
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/models"
+)
+
+func TransformHDDSpecs(specs []models.HDDSpec) []dto.SpecTypeResp {
+	result := make([]dto.SpecTypeResp, 0, len(specs))
+	for _, spec := range specs {
+		result = append(result, dto.SpecTypeResp{ID: spec.ID, Type: spec.Type})
+	}
+	return result
+}
+
+func TransformRAMSpecs(specs []models.RamSpec) []dto.SpecTypeResp {
+	result := make([]dto.SpecTypeResp, 0, len(specs))
+	for _, spec := range specs {
+		result = append(result, dto.SpecTypeResp{ID: spec.ID, Type: spec.Type})
+	}
+	return result
+}
+
+// TransformCurrency converts a currency into its response, the type column holds its name
+func TransformCurrency(currency models.Currency) dto.CurrencyResp {
+	return dto.CurrencyResp{
+		ID:                 currency.ID,
+		Code:               currency.Code,
+		Name:               currency.Type,
+		Symbol:             currency.Symbol,
+		SymbolPosition:     currency.SymbolPosition,
+		Decimals:           currency.Decimals,
+		DecimalSeparator:   currency.DecimalSeparator,
+		ThousandsSeparator: currency.ThousandsSeparator,
+		Example:            currency.Format(1234.5),
+	}
+}
+
+func TransformCurrencies(currencies []models.Currency) []dto.CurrencyResp {
+	result := make([]dto.CurrencyResp, 0, len(currencies))
+	for _, currency := range currencies {
+		result = append(result, TransformCurrency(currency))
+	}
+	return result
+}
@@ -1,67 +1,174 @@
 package transformer
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/lookup"
 	"github.com/server-catalog/internal/utils"
 	"github.com/server-catalog/models"
+	"strings"
 )
 
-func TransformServerList(servers []models.ServerCatalog) []dto.ListServerResp {
+// TransformServerList renders the servers with the spec types and currencies of lookups
+func TransformServerList(servers []models.ServerCatalog, lookups *lookup.Registry) []dto.ListServerResp {
 	result := make([]dto.ListServerResp, 0)
 
 	for _, server := range servers {
-		var ramType string
-		switch server.RamType {
-		case utils.RAMTypeDDR3:
-			ramType = "DDR3"
-		case utils.RAMTypeDDR4:
-			ramType = "DDR4"
-		default:
-			ramType = ""
-		}
-		ram := fmt.Sprintf("%dGB%s", server.RamSize, ramType)
+		ram := TransformRAM(server, lookups)
 
-		hddSize := server.HDDSize
-		hddUnit := utils.HDDUnitGB
-		if hddSize >= 1024 {
-			hddSize = hddSize / 1024
-			hddUnit = utils.HDDUnitTB
-		}
+		hdd := TransformDisks(server.Disks, lookups)
 
-		var hddType string
-		switch server.HDDType {
-		case utils.HDDTypeSATA2:
-			hddType = utils.HDDSATA2DB
-		case utils.HDDTypeSAS:
-			hddType = utils.HDDSASDB
-		case utils.HDDTypeSSD:
-			hddType = utils.HDDSSDDB
-		default:
-			hddType = ""
-		}
-		hdd := fmt.Sprintf("%dx%d%s%s", server.HDDCount, hddSize, hddUnit, hddType)
-
-		var price string
-		switch server.Currency {
-		case 1: // USD
-			price = fmt.Sprintf("$%.2f", server.Price)
-		case 2: // Euro
-			price = fmt.Sprintf("€%.2f", server.Price)
-		case 3: // SGD
-			price = fmt.Sprintf("S$%.2f", server.Price)
-		default:
-			price = fmt.Sprintf("%.2f", server.Price)
+		price := fmt.Sprintf("%.2f", server.Price)
+		if currency, ok := lookups.Currency(server.Currency); ok {
+			price = currency.Format(server.Price)
 		}
 
-		result = append(result, dto.ListServerResp{
+		resp := dto.ListServerResp{
 			Model:    server.Model,
 			Ram:      ram,
 			HDD:      hdd,
 			Location: server.Location,
 			Price:    price,
-		})
+		}
+		if server.ServerModel != nil {
+			resp.Vendor = server.ServerModel.Vendor
+			resp.Chassis = server.ServerModel.Chassis
+			resp.CPU = server.ServerModel.CPU
+		}
+		result = append(result, resp)
 
 	}
 	return result
 }
+
+func TransformLocations(locations []models.Location) []dto.LocationResp {
+	result := make([]dto.LocationResp, 0, len(locations))
+	for _, location := range locations {
+		result = append(result, dto.LocationResp{
+			Name:    location.Name,
+			City:    location.City,
+			Code:    location.Code,
+			Country: location.Country,
+		})
+	}
+	return result
+}
+
+// TransformRAM renders the memory of a server, e.g. 16GBDDR3, 1TBDDR4 or 64GBDDR5-4800 ECC
+func TransformRAM(server models.ServerCatalog, lookups *lookup.Registry) string {
+	ramType := lookups.RAMTypeName(server.RamType)
+
+	ramSize := server.RamSize
+	ramUnit := utils.HDDUnitGB
+	if ramSize >= 1024 && ramSize%1024 == 0 {
+		ramSize = ramSize / 1024
+		ramUnit = utils.HDDUnitTB
+	}
+
+	ram := fmt.Sprintf("%d%s%s", ramSize, ramUnit, ramType)
+	if server.RamSpeed > 0 {
+		ram += fmt.Sprintf("-%d", server.RamSpeed)
+	}
+	if server.RamECC {
+		ram += " ECC"
+	}
+	return ram
+}
+
+// TransformDisks renders every disk group of a server, e.g. 2x120GBSSD+4x2TBSATA2
+func TransformDisks(disks []models.ServerDisk, lookups *lookup.Registry) string {
+	groups := make([]string, 0, len(disks))
+	for _, disk := range disks {
+		hddSize := disk.HDDSize
+		hddUnit := utils.HDDUnitGB
+		if hddSize >= 1024 && hddSize%1024 == 0 {
+			hddSize = hddSize / 1024
+			hddUnit = utils.HDDUnitTB
+		}
+
+		groups = append(groups, fmt.Sprintf("%dx%d%s%s", disk.HDDCount, hddSize, hddUnit, lookups.HDDTypeName(disk.HDDType)))
+	}
+	return strings.Join(groups, "+")
+}
+
+// TransformPreviewRow converts a validated catalog row into its dry-run representation
+func TransformPreviewRow(sheet string, row int, server models.ServerCatalog) dto.CatalogPreviewRow {
+	disks := make([]dto.CatalogPreviewDisk, 0, len(server.Disks))
+	for _, disk := range server.Disks {
+		disks = append(disks, dto.CatalogPreviewDisk{HDDCount: disk.HDDCount, HDDSize: disk.HDDSize, HDDTypeID: disk.HDDType})
+	}
+	return dto.CatalogPreviewRow{
+		Sheet:      sheet,
+		Row:        row,
+		Model:      server.Model,
+		RamSize:    server.RamSize,
+		RamTypeID:  server.RamType,
+		RamECC:     server.RamECC,
+		RamSpeed:   server.RamSpeed,
+		Disks:      disks,
+		Storage:    server.Storage(),
+		Location:   server.Location,
+		Price:      server.Price,
+		CurrencyID: server.Currency,
+	}
+}
+
+// TransformUploadJob converts a persisted upload job into its status response
+func TransformUploadJob(job *models.UploadJob) *dto.UploadJobResp {
+	resp := &dto.UploadJobResp{
+		ID:            job.ID,
+		State:         job.State,
+		RowsProcessed: job.RowsProcessed,
+		Error:         job.Error,
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+	}
+	if job.Report != "" {
+		_ = json.Unmarshal([]byte(job.Report), &resp.Report)
+	}
+	if job.Result != "" {
+		_ = json.Unmarshal([]byte(job.Result), &resp.Result)
+	}
+	return resp
+}
+
+// TransformCatalogVersion converts a catalog version into its response
+func TransformCatalogVersion(version *models.CatalogVersion) dto.CatalogVersionResp {
+	return dto.CatalogVersionResp{
+		ID:          version.ID,
+		Mode:        version.Mode,
+		ServerCount: version.ServerCount,
+		Active:      version.Active,
+		CreatedAt:   version.CreatedAt,
+		ActivatedAt: version.ActivatedAt,
+	}
+}
+
+// TransformUploads converts the upload history into its response
+func TransformUploads(uploads []models.Upload) []dto.UploadResp {
+	resp := make([]dto.UploadResp, 0, len(uploads))
+	for _, upload := range uploads {
+		resp = append(resp, dto.UploadResp{
+			ID:        upload.ID,
+			Source:    upload.Source,
+			Filename:  upload.Filename,
+			Size:      upload.Size,
+			Checksum:  upload.Checksum,
+			Uploader:  upload.Uploader,
+			Mode:      upload.Mode,
+			Forced:    upload.Forced,
+			Outcome:   upload.Outcome,
+			Error:     upload.Error,
+			Rows:      upload.Rows,
+			Inserted:  upload.Inserted,
+			Updated:   upload.Updated,
+			Unchanged: upload.Unchanged,
+			Deleted:   upload.Deleted,
+			Version:   upload.VersionID,
+			StartedAt: upload.StartedAt,
+			EndedAt:   upload.EndedAt,
+		})
+	}
+	return resp
+}
@@ -2,6 +2,7 @@ package transformer
 
 import (
 	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/lookup"
 	"github.com/server-catalog/internal/utils"
 	"github.com/server-catalog/models"
 	"github.com/stretchr/testify/assert"
@@ -21,9 +22,7 @@ func TestTransformServerList(t *testing.T) {
 					Model:    "Dell R210",
 					RamSize:  16,
 					RamType:  utils.RAMTypeDDR3,
-					HDDSize:  500,
-					HDDCount: 2,
-					HDDType:  utils.HDDTypeSATA2,
+					Disks:    []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: utils.HDDTypeSATA2}},
 					Location: "AmsterdamAMS-01",
 					Price:    99.99,
 					Currency: 2,
@@ -46,9 +45,7 @@ func TestTransformServerList(t *testing.T) {
 					Model:    "Dell R730XD",
 					RamSize:  128,
 					RamType:  utils.RAMTypeDDR4,
-					HDDSize:  2048, // 2TB
-					HDDCount: 4,
-					HDDType:  utils.HDDTypeSSD,
+					Disks:    []models.ServerDisk{{HDDCount: 4, HDDSize: 2048, HDDType: utils.HDDTypeSSD}}, // 2TB
 					Location: "SingaporeSIN-11",
 					Price:    565.99,
 					Currency: 3,
@@ -71,9 +68,7 @@ func TestTransformServerList(t *testing.T) {
 					Model:    "HP DL380",
 					RamSize:  64,
 					RamType:  utils.RAMTypeDDR3,
-					HDDSize:  1024, // 1TB
-					HDDCount: 8,
-					HDDType:  utils.HDDTypeSAS,
+					Disks:    []models.ServerDisk{{HDDCount: 8, HDDSize: 1024, HDDType: utils.HDDTypeSAS}}, // 1TB
 					Location: "Washington D.C.WDC-01",
 					Price:    199.99,
 					Currency: 1,
@@ -96,9 +91,7 @@ func TestTransformServerList(t *testing.T) {
 					Model:    "Dell R730XD2x Intel Xeon E5-2620v4",
 					RamSize:  32,
 					RamType:  utils.RAMTypeDDR4,
-					HDDSize:  500,
-					HDDCount: 2,
-					HDDType:  utils.HDDTypeSATA2,
+					Disks:    []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: utils.HDDTypeSATA2}},
 					Location: "AmsterdamAMS-01",
 					Price:    149.99,
 					Currency: 2,
@@ -107,9 +100,7 @@ func TestTransformServerList(t *testing.T) {
 					Model:    "Dell R730XD2x Intel Xeon E5-2620v4",
 					RamSize:  64,
 					RamType:  utils.RAMTypeDDR3,
-					HDDSize:  2048,
-					HDDCount: 4,
-					HDDType:  utils.HDDTypeSSD,
+					Disks:    []models.ServerDisk{{HDDCount: 4, HDDSize: 2048, HDDType: utils.HDDTypeSSD}},
 					Location: "SingaporeSIN-11",
 					Price:    299.99,
 					Currency: 3,
@@ -139,9 +130,7 @@ func TestTransformServerList(t *testing.T) {
 					Model:    "Dell R730XD2x Intel Xeon E5-2650v3",
 					RamSize:  16,
 					RamType:  999, // Unknown type
-					HDDSize:  500,
-					HDDCount: 2,
-					HDDType:  utils.HDDTypeSATA2,
+					Disks:    []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: utils.HDDTypeSATA2}},
 					Location: "AmsterdamAMS-01",
 					Price:    99.99,
 					Currency: 2,
@@ -164,9 +153,7 @@ func TestTransformServerList(t *testing.T) {
 					Model:    "IBM X3650M42x Intel Xeon E5-2620",
 					RamSize:  16,
 					RamType:  utils.RAMTypeDDR3,
-					HDDSize:  500,
-					HDDCount: 2,
-					HDDType:  999, // Unknown type
+					Disks:    []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: 999}}, // Unknown type
 					Location: "AmsterdamAMS-01",
 					Price:    99.99,
 					Currency: 2,
@@ -182,6 +169,100 @@ func TestTransformServerList(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "transform server with mixed disk groups",
+			input: []models.ServerCatalog{
+				{
+					Model:   "Supermicro SC846E26-R1200Intel Xeon E5-2620",
+					RamSize: 32,
+					RamType: utils.RAMTypeDDR4,
+					Disks: []models.ServerDisk{
+						{HDDCount: 2, HDDSize: 120, HDDType: utils.HDDTypeSSD},
+						{HDDCount: 4, HDDSize: 2048, HDDType: utils.HDDTypeSATA2},
+					},
+					Location: "FrankfurtFRA-10",
+					Price:    199.99,
+					Currency: 2,
+				},
+			},
+			expected: []dto.ListServerResp{
+				{
+					Model:    "Supermicro SC846E26-R1200Intel Xeon E5-2620",
+					Ram:      "32GBDDR4",
+					HDD:      "2x120GBSSD+4x2TBSATA2",
+					Location: "FrankfurtFRA-10",
+					Price:    "€199.99",
+				},
+			},
+		},
+		{
+			name: "transform server with ECC DDR5 memory",
+			input: []models.ServerCatalog{
+				{
+					Model:    "Dell R760Intel Xeon Gold 5415+",
+					RamSize:  128,
+					RamType:  utils.RAMTypeDDR5,
+					RamECC:   true,
+					RamSpeed: 4800,
+					Disks:    []models.ServerDisk{{HDDCount: 2, HDDSize: 960, HDDType: utils.HDDTypeSSD}},
+					Location: "AmsterdamAMS-01",
+					Price:    399.99,
+					Currency: 2,
+				},
+				{
+					Model:    "HP DL560G10Intel Xeon Platinum 8160",
+					RamSize:  1024,
+					RamType:  utils.RAMTypeDDR4,
+					Disks:    []models.ServerDisk{{HDDCount: 2, HDDSize: 960, HDDType: utils.HDDTypeSSD}},
+					Location: "AmsterdamAMS-01",
+					Price:    899.99,
+					Currency: 2,
+				},
+			},
+			expected: []dto.ListServerResp{
+				{
+					Model:    "Dell R760Intel Xeon Gold 5415+",
+					Ram:      "128GBDDR5-4800 ECC",
+					HDD:      "2x960GBSSD",
+					Location: "AmsterdamAMS-01",
+					Price:    "€399.99",
+				},
+				{
+					Model:    "HP DL560G10Intel Xeon Platinum 8160",
+					Ram:      "1TBDDR4",
+					HDD:      "2x960GBSSD",
+					Location: "AmsterdamAMS-01",
+					Price:    "€899.99",
+				},
+			},
+		},
+		{
+			name: "transform server with model parts",
+			input: []models.ServerCatalog{
+				{
+					Model:       "HP DL120G7Intel G850",
+					ServerModel: &models.ServerModel{Name: "HP DL120G7Intel G850", Vendor: "HP", Chassis: "DL120G7", CPU: "Intel G850"},
+					RamSize:     4,
+					RamType:     utils.RAMTypeDDR3,
+					Disks:       []models.ServerDisk{{HDDCount: 4, HDDSize: 1024, HDDType: utils.HDDTypeSATA2}},
+					Location:    "AmsterdamAMS-01",
+					Price:       39.99,
+					Currency:    2,
+				},
+			},
+			expected: []dto.ListServerResp{
+				{
+					Model:    "HP DL120G7Intel G850",
+					Vendor:   "HP",
+					Chassis:  "DL120G7",
+					CPU:      "Intel G850",
+					Ram:      "4GBDDR3",
+					HDD:      "4x1TBSATA2",
+					Location: "AmsterdamAMS-01",
+					Price:    "€39.99",
+				},
+			},
+		},
 		{
 			name: "transform server with unknown currency",
 			input: []models.ServerCatalog{
@@ -189,9 +270,7 @@ func TestTransformServerList(t *testing.T) {
 					Model:    "Dell R730XD2x Intel Xeon E5-2650v3",
 					RamSize:  16,
 					RamType:  utils.RAMTypeDDR3,
-					HDDSize:  500,
-					HDDCount: 2,
-					HDDType:  utils.HDDTypeSATA2,
+					Disks:    []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: utils.HDDTypeSATA2}},
 					Location: "AmsterdamAMS-01",
 					Price:    99.99,
 					Currency: 999,
@@ -211,8 +290,34 @@ func TestTransformServerList(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := TransformServerList(tt.input)
+			result := TransformServerList(tt.input, lookup.Static(lookup.Seed))
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
+
+func TestTransformServerList_Lookups(t *testing.T) {
+	// types and currencies added to the lookup tables are rendered without code changes
+	lookups := lookup.Static(lookup.Tables{
+		HDDSpecs:   []models.HDDSpec{{ID: 4, Type: "NVMe"}},
+		RAMSpecs:   []models.RamSpec{{ID: 4, Type: "DDR6"}},
+		Currencies: []models.Currency{{ID: 4, Code: "JPY", Type: "Yen", Symbol: "¥", SymbolPosition: models.SymbolBefore, ThousandsSeparator: ","}},
+	})
+
+	result := TransformServerList([]models.ServerCatalog{{
+		Model:    "Dell R7615AMD EPYC 9354P",
+		RamSize:  128,
+		RamType:  4,
+		Disks:    []models.ServerDisk{{HDDCount: 2, HDDSize: 1024, HDDType: 4}},
+		Location: "LondonLON-01",
+		Price:    34900,
+		Currency: 4,
+	}}, lookups)
+	assert.Equal(t, []dto.ListServerResp{{
+		Model:    "Dell R7615AMD EPYC 9354P",
+		Ram:      "128GBDDR6",
+		HDD:      "2x1TBNVMe",
+		Location: "LondonLON-01",
+		Price:    "¥34,900",
+	}}, result)
+}
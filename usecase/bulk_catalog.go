@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"io"
+	"os"
+	"strings"
+)
+
+// BulkUpload validates structured server records sent as a JSON array or as
+// NDJSON and stores them. Records go through the same rules as spreadsheet rows.
+// The body is spooled to disk and read twice, once to validate and once to store
+// the records, so memory use doesn't grow with the number of records.
+func (sc *ServerCatalog) BulkUpload(ctx context.Context, ctr *dto.BulkUploadCtr) (*dto.UploadCatalogResp, error) {
+	mode, err := uploadMode(ctr.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sc.spoolBody(ctr.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	upload, err := sc.startUpload(ctx, &models.Upload{
+		Source:   models.UploadSourceBulk,
+		Size:     body.size,
+		Checksum: body.checksum,
+		Uploader: ctr.Uploader,
+		Mode:     mode,
+	}, ctr.Force)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := sc.storeRecords(ctx, mode, body)
+	sc.finishUpload(ctx, upload, resp, err)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// storeRecords validates and stores the records of a spooled bulk upload, see BulkUpload
+func (sc *ServerCatalog) storeRecords(ctx context.Context, mode string, body *spooledBody) (*dto.UploadCatalogResp, error) {
+	var count int
+	report := &utils.ValidationError{}
+	err := decodeRecords(body.reader(), func(n int, record dto.BulkServerRecord) error {
+		if err := sc.maxRowsExceeded(n); err != nil {
+			return err
+		}
+		count = n
+		if _, recordErrs := sc.parseRecord(n, record); len(recordErrs) > 0 {
+			report.Rows = append(report.Rows, recordErrs...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("usecase:server_catalog:no servers in the request")
+	}
+	if len(report.Rows) > 0 {
+		return nil, report
+	}
+
+	return sc.storeCatalog(ctx, mode, func(add func(catalog models.ServerCatalog) error) error {
+		return decodeRecords(body.reader(), func(n int, record dto.BulkServerRecord) error {
+			catalog, _ := sc.parseRecord(n, record)
+			return add(catalog)
+		})
+	}, nil)
+}
+
+// spooledBody is a request body copied to a temporary file, Close removes the file
+type spooledBody struct {
+	file     *os.File
+	size     int64
+	checksum string
+}
+
+// spoolBody copies a request body to the spool directory of the upload policy
+func (sc *ServerCatalog) spoolBody(r io.Reader) (*spooledBody, error) {
+	var dir string
+	if sc.Policy != nil {
+		dir = sc.Policy.SpoolDir
+	}
+	f, err := os.CreateTemp(dir, "bulk-*")
+	if err != nil {
+		return nil, fmt.Errorf("usecase:server_catalog:failed to spool request body %v", err)
+	}
+
+	body := &spooledBody{file: f}
+	digest := newDigestReader(r)
+	if _, err := io.Copy(f, digest); err != nil {
+		_ = body.Close()
+		return nil, fmt.Errorf("usecase:server_catalog:failed to read request body %w", err)
+	}
+	body.size, body.checksum = digest.size, digest.checksum()
+	return body, nil
+}
+
+// reader reads the body from its start
+func (sb *spooledBody) reader() io.Reader {
+	return io.NewSectionReader(sb.file, 0, sb.size)
+}
+
+func (sb *spooledBody) Close() error {
+	err := sb.file.Close()
+	if rmErr := os.Remove(sb.file.Name()); err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// decodeRecords reads either a JSON array of records or a stream of newline delimited
+// records and hands every record to fn along with its position, starting at 1
+func decodeRecords(body io.Reader, fn func(n int, record dto.BulkServerRecord) error) error {
+	br := bufio.NewReader(body)
+	dec := json.NewDecoder(br)
+
+	first, err := firstNonSpace(br)
+	if err != nil {
+		return fmt.Errorf("usecase:server_catalog:empty request body")
+	}
+
+	n := 0
+	if first == '[' {
+		if _, err := dec.Token(); err != nil {
+			return fmt.Errorf("usecase:server_catalog:invalid JSON body: %v", err)
+		}
+		for dec.More() {
+			var record dto.BulkServerRecord
+			if err := dec.Decode(&record); err != nil {
+				return fmt.Errorf("usecase:server_catalog:invalid record %d: %v", n+1, err)
+			}
+			n++
+			if err := fn(n, record); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return fmt.Errorf("usecase:server_catalog:invalid JSON body: %v", err)
+		}
+		return nil
+	}
+
+	for {
+		var record dto.BulkServerRecord
+		err := dec.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("usecase:server_catalog:invalid record %d: %v", n+1, err)
+		}
+		n++
+		if err := fn(n, record); err != nil {
+			return err
+		}
+	}
+}
+
+// firstNonSpace returns the first non whitespace byte of the reader without consuming it
+func firstNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if !strings.ContainsRune(" \t\r\n", rune(b)) {
+			return b, br.UnreadByte()
+		}
+	}
+}
+
+// parseRecord validates a structured server record and converts it into a catalog entry.
+// Errors are reported per field, n is the position of the record in the request.
+func (sc *ServerCatalog) parseRecord(n int, record dto.BulkServerRecord) (models.ServerCatalog, []utils.RowError) {
+	var catalog models.ServerCatalog
+	var errs []utils.RowError
+
+	fail := func(field string, value interface{}, reason string) {
+		errs = append(errs, utils.RowError{
+			Row:    n,
+			Column: field,
+			Cell:   fmt.Sprintf("[%d].%s", n, field),
+			Value:  fmt.Sprint(value),
+			Reason: reason,
+		})
+	}
+
+	catalog.Model = strings.TrimSpace(record.Model)
+	if catalog.Model == "" {
+		fail("model", record.Model, "model is required")
+	}
+
+	if record.RamGB < 1 {
+		fail("ram_gb", record.RamGB, "RAM size must be positive")
+	}
+	catalog.RamSize = record.RamGB
+	if ramTypeID, err := sc.Lookups.RAMTypeID(strings.TrimSpace(record.RamType)); err != nil {
+		fail("ram_type", record.RamType, err.Error())
+	} else {
+		catalog.RamType = ramTypeID
+	}
+	if record.RamSpeed < 0 {
+		fail("ram_speed", record.RamSpeed, "RAM speed must not be negative")
+	}
+	catalog.RamSpeed = record.RamSpeed
+	catalog.RamECC = record.RamECC
+
+	if len(record.Disks) > 0 {
+		for i, disk := range record.Disks {
+			field := fmt.Sprintf("disks[%d].", i)
+			catalog.Disks = append(catalog.Disks, sc.parseDisk(disk.Count, disk.SizeGB, disk.Type,
+				field+"count", field+"size_gb", field+"type", fail))
+		}
+	} else {
+		catalog.Disks = append(catalog.Disks, sc.parseDisk(record.DiskCount, record.DiskSizeGB, record.DiskType,
+			"disk_count", "disk_size_gb", "disk_type", fail))
+	}
+
+	catalog.Location = strings.TrimSpace(record.Location)
+	if catalog.Location == "" {
+		fail("location", record.Location, "location is required")
+	}
+
+	if record.Price < 0 {
+		fail("price", record.Price, "price must not be negative")
+	}
+	catalog.Price = record.Price
+	if currencyID, err := sc.Lookups.CurrencyIDByCode(record.Currency); err == nil {
+		catalog.Currency = currencyID
+	} else if currencyID, err := sc.Lookups.CurrencyIDBySymbol(record.Currency); err == nil {
+		catalog.Currency = currencyID
+	} else {
+		fail("currency", record.Currency, fmt.Sprintf("unknown currency: %s", record.Currency))
+	}
+
+	return catalog, errs
+}
+
+// parseDisk validates a single disk group of a record, invalid values are reported through fail
+func (sc *ServerCatalog) parseDisk(count, sizeGB int, typ, countField, sizeField, typeField string,
+	fail func(field string, value interface{}, reason string)) models.ServerDisk {
+	disk := models.ServerDisk{HDDCount: count, HDDSize: sizeGB}
+	if count < 1 {
+		fail(countField, count, "disk count must be positive")
+	}
+	if sizeGB < 1 {
+		fail(sizeField, sizeGB, "disk size must be positive")
+	}
+	if hddTypeID, err := sc.Lookups.HDDTypeID(strings.TrimSpace(typ)); err != nil {
+		fail(typeField, typ, err.Error())
+	} else {
+		disk.HDDType = hddTypeID
+	}
+	return disk
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/server-catalog/internal/config"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestServerCatalog_BulkUpload(t *testing.T) {
+	expected := []models.ServerCatalog{
+		{
+			Model:      "Dell R210-II",
+			RamSize:    16,
+			RamType:    utils.RAMTypeDDR3,
+			Disks:      []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: utils.HDDTypeSATA2}},
+			Location:   "AmsterdamAMS-01",
+			Price:      35.99,
+			Currency:   utils.CurrencyUSD,
+			VersionID:  1,
+			ModelID:    1,
+			LocationID: 1,
+		},
+		{
+			Model:      "HP DL120G7",
+			RamSize:    4,
+			RamType:    utils.RAMTypeDDR4,
+			Disks:      []models.ServerDisk{{HDDCount: 4, HDDSize: 1024, HDDType: utils.HDDTypeSSD}},
+			Location:   "FrankfurtFRA-10",
+			Price:      39.99,
+			Currency:   utils.CurrencyEuro,
+			VersionID:  1,
+			ModelID:    2,
+			LocationID: 2,
+		},
+	}
+
+	tests := []struct {
+		name          string
+		body          string
+		expected      []models.ServerCatalog
+		expectedError error
+	}{
+		{
+			name: "json array",
+			body: `[
+				{"model":"Dell R210-II","ram_gb":16,"ram_type":"DDR3","disk_count":2,"disk_size_gb":500,"disk_type":"SATA2","location":"AmsterdamAMS-01","price":35.99,"currency":"USD"},
+				{"model":"HP DL120G7","ram_gb":4,"ram_type":"ddr4","disk_count":4,"disk_size_gb":1024,"disk_type":"SSD","location":"FrankfurtFRA-10","price":39.99,"currency":"€"}
+			]`,
+			expected: expected,
+		},
+		{
+			name: "ndjson",
+			body: `{"model":"Dell R210-II","ram_gb":16,"ram_type":"DDR3","disk_count":2,"disk_size_gb":500,"disk_type":"SATA2","location":"AmsterdamAMS-01","price":35.99,"currency":"$"}
+{"model":"HP DL120G7","ram_gb":4,"ram_type":"DDR4","disk_count":4,"disk_size_gb":1024,"disk_type":"ssd","location":"FrankfurtFRA-10","price":39.99,"currency":"eur"}
+`,
+			expected: expected,
+		},
+		{
+			name: "disk groups and memory details",
+			body: `[{"model":"HP DL120G7","ram_gb":4,"ram_type":"DDR4","ram_ecc":true,"ram_speed":2400,"disks":[{"count":2,"size_gb":120,"type":"SSD"},{"count":4,"size_gb":2048,"type":"SATA2"}],"location":"FrankfurtFRA-10","price":39.99,"currency":"EUR"}]`,
+			expected: []models.ServerCatalog{
+				{
+					Model:    "HP DL120G7",
+					RamSize:  4,
+					RamType:  utils.RAMTypeDDR4,
+					RamECC:   true,
+					RamSpeed: 2400,
+					Disks: []models.ServerDisk{
+						{HDDCount: 2, HDDSize: 120, HDDType: utils.HDDTypeSSD},
+						{HDDCount: 4, HDDSize: 2048, HDDType: utils.HDDTypeSATA2},
+					},
+					Location:   "FrankfurtFRA-10",
+					Price:      39.99,
+					Currency:   utils.CurrencyEuro,
+					VersionID:  1,
+					ModelID:    1,
+					LocationID: 1,
+				},
+			},
+		},
+		{
+			name:          "empty body",
+			body:          "  \n",
+			expectedError: errors.New("usecase:server_catalog:empty request body"),
+		},
+		{
+			name:          "empty array",
+			body:          "[]",
+			expectedError: errors.New("usecase:server_catalog:no servers in the request"),
+		},
+		{
+			name:          "malformed ndjson",
+			body:          `{"model":"Dell R210-II"}` + "\n" + `{"model":`,
+			expectedError: errors.New("usecase:server_catalog:invalid record 2: unexpected EOF"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uploaded []models.ServerCatalog
+			mockRepo := &mockCatalogRepository{
+				uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+					uploaded = catalogs
+					return nil
+				},
+			}
+
+			uc := New(mockRepo, testPolicy, testLookups)
+			_, err := uc.BulkUpload(context.Background(), &dto.BulkUploadCtr{Body: strings.NewReader(tt.body)})
+			if (err != nil && tt.expectedError == nil) ||
+				(err == nil && tt.expectedError != nil) ||
+				(err != nil && tt.expectedError != nil && err.Error() != tt.expectedError.Error()) {
+				t.Fatalf("BulkUpload() error = %v, want %v", err, tt.expectedError)
+			}
+
+			if !reflect.DeepEqual(uploaded, tt.expected) {
+				t.Errorf("BulkUpload() uploaded %+v, want %+v", uploaded, tt.expected)
+			}
+		})
+	}
+}
+
+func TestServerCatalog_BulkUpload_RecordErrors(t *testing.T) {
+	body := `[
+		{"model":"Dell R210-II","ram_gb":16,"ram_type":"DDR3","disk_count":2,"disk_size_gb":500,"disk_type":"SATA2","location":"AmsterdamAMS-01","price":35.99,"currency":"USD"},
+		{"model":"","ram_gb":0,"ram_type":"DDR9","disk_count":2,"disk_size_gb":500,"disk_type":"SATA2","location":"AmsterdamAMS-01","price":-1,"currency":"JPY"},
+		{"model":"HP DL120G7","ram_gb":4,"ram_type":"DDR4","disks":[{"count":2,"size_gb":120,"type":"SSD"},{"count":0,"size_gb":2048,"type":"NVME"}],"location":"FrankfurtFRA-10","price":39.99,"currency":"EUR"}
+	]`
+
+	mockRepo := &mockCatalogRepository{
+		uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+			t.Error("BulkUpload() must not upload invalid records")
+			return nil
+		},
+	}
+
+	uc := New(mockRepo, testPolicy, testLookups)
+	_, err := uc.BulkUpload(context.Background(), &dto.BulkUploadCtr{Body: strings.NewReader(body)})
+
+	var verr *utils.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("BulkUpload() error = %v, want *utils.ValidationError", err)
+	}
+
+	expected := []utils.RowError{
+		{Row: 2, Column: "model", Cell: "[2].model", Value: "", Reason: "model is required"},
+		{Row: 2, Column: "ram_gb", Cell: "[2].ram_gb", Value: "0", Reason: "RAM size must be positive"},
+		{Row: 2, Column: "ram_type", Cell: "[2].ram_type", Value: "DDR9", Reason: "unknown RAM type: DDR9"},
+		{Row: 2, Column: "price", Cell: "[2].price", Value: "-1", Reason: "price must not be negative"},
+		{Row: 2, Column: "currency", Cell: "[2].currency", Value: "JPY", Reason: "unknown currency: JPY"},
+		{Row: 3, Column: "disks[1].count", Cell: "[3].disks[1].count", Value: "0", Reason: "disk count must be positive"},
+		{Row: 3, Column: "disks[1].type", Cell: "[3].disks[1].type", Value: "NVME", Reason: "unknown HDD type: NVME"},
+	}
+	if !reflect.DeepEqual(verr.Rows, expected) {
+		t.Errorf("BulkUpload() errors = %+v, want %+v", verr.Rows, expected)
+	}
+}
+
+func TestServerCatalog_BulkUpload_MaxRows(t *testing.T) {
+	record := `{"model":"Dell R210-II","ram_gb":16,"ram_type":"DDR3","disk_count":2,"disk_size_gb":500,"disk_type":"SATA2","location":"AmsterdamAMS-01","price":35.99,"currency":"USD"}`
+	// the limit is hit while decoding, before the malformed record is reached
+	body := strings.Join([]string{record, record, record, `{"model":`}, "\n")
+
+	mockRepo := &mockCatalogRepository{
+		uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+			t.Error("BulkUpload() must not upload more records than allowed")
+			return nil
+		},
+	}
+
+	uc := New(mockRepo, &config.UploadPolicy{MaxRows: 2}, testLookups)
+	_, err := uc.BulkUpload(context.Background(), &dto.BulkUploadCtr{Body: strings.NewReader(body)})
+	expected := "usecase:server_catalog:maximum number of rows exceeded (2)"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("BulkUpload() error = %v, want %v", err, expected)
+	}
+}
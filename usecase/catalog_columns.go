@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"strings"
+)
+
+// columnLayout holds the position of every catalog column within the rows of a sheet
+type columnLayout [colCount]int
+
+// columnAliases returns the header names accepted for every catalog column: the
+// canonical name followed by the aliases configured in the upload policy
+func (sc *ServerCatalog) columnAliases() [colCount][]string {
+	var aliases [colCount][]string
+	for col, name := range catalogColumns {
+		aliases[col] = []string{name}
+		if sc.Policy != nil {
+			aliases[col] = append(aliases[col], sc.Policy.Columns[strings.ToLower(name)]...)
+		}
+	}
+	return aliases
+}
+
+// resolveColumns finds the catalog columns in a header row by name. Columns may
+// come in any order, header names are compared case-insensitively and unknown
+// columns are ignored. Every column that is missing or matched more than once is
+// reported.
+func resolveColumns(header []string, aliases [colCount][]string) (columnLayout, error) {
+	var layout columnLayout
+	matches := make([][]int, colCount)
+	for i, name := range header {
+		name = normalizeHeader(name)
+		if name == "" {
+			continue
+		}
+		for col := range aliases {
+			for _, alias := range aliases[col] {
+				if normalizeHeader(alias) == name {
+					matches[col] = append(matches[col], i)
+					break
+				}
+			}
+		}
+	}
+
+	var problems []string
+	for col, found := range matches {
+		switch len(found) {
+		case 1:
+			layout[col] = found[0]
+		case 0:
+			problems = append(problems, fmt.Sprintf("missing %s (accepted: %s)",
+				catalogColumns[col], strings.Join(aliases[col], ", ")))
+		default:
+			names := make([]string, 0, len(found))
+			for _, i := range found {
+				name, _ := excelize.ColumnNumberToName(i + 1)
+				names = append(names, name)
+			}
+			problems = append(problems, fmt.Sprintf("ambiguous %s (columns %s)",
+				catalogColumns[col], strings.Join(names, ", ")))
+		}
+	}
+	if len(problems) > 0 {
+		return layout, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return layout, nil
+}
+
+// normalizeHeader lowercases a header name and collapses its whitespace
+func normalizeHeader(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/server-catalog/internal/config"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"reflect"
+	"testing"
+)
+
+func TestResolveColumns(t *testing.T) {
+	uc := &ServerCatalog{Policy: &config.UploadPolicy{
+		Columns: map[string][]string{
+			"model":    {"Server Model"},
+			"ram":      {"Memory"},
+			"hdd":      {"Storage"},
+			"location": {"DC"},
+			"price":    {"Monthly Price"},
+		},
+	}}
+
+	tests := []struct {
+		name          string
+		header        []string
+		expected      columnLayout
+		expectedError error
+	}{
+		{
+			name:     "canonical header",
+			header:   []string{"Model", "RAM", "HDD", "Location", "Price"},
+			expected: columnLayout{0, 1, 2, 3, 4},
+		},
+		{
+			name:     "aliases in any order with extra columns",
+			header:   []string{"SKU", "monthly  price", "DC", "Server Model", "Notes", "STORAGE", "Memory"},
+			expected: columnLayout{3, 6, 5, 2, 1},
+		},
+		{
+			name:          "missing column",
+			header:        []string{"Model", "RAM", "HDD", "Price"},
+			expectedError: errors.New("missing Location (accepted: Location, DC)"),
+		},
+		{
+			name:          "ambiguous column",
+			header:        []string{"Model", "RAM", "Memory", "HDD", "Location", "Price"},
+			expectedError: errors.New("ambiguous RAM (columns B, C)"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout, err := resolveColumns(tt.header, uc.columnAliases())
+			if (err != nil && tt.expectedError == nil) ||
+				(err == nil && tt.expectedError != nil) ||
+				(err != nil && tt.expectedError != nil && err.Error() != tt.expectedError.Error()) {
+				t.Fatalf("resolveColumns() error = %v, want %v", err, tt.expectedError)
+			}
+			if tt.expectedError == nil && layout != tt.expected {
+				t.Errorf("resolveColumns() = %v, want %v", layout, tt.expected)
+			}
+		})
+	}
+}
+
+func TestServerCatalog_UploadCatalog_ColumnAliases(t *testing.T) {
+	excelBuffer, err := createTestExcelFile([][]string{
+		{"DC", "Server Model", "Comment", "Memory", "Storage", "Monthly Price"},
+		{"AmsterdamAMS-01", "Dell R210-II", "boot SSD", "16GB DDR3", "2x500GBSATA2", "$35.99"},
+		{"AmsterdamAMS-01", "HP DL120G7", "", "4GB", "4x1TBSATA2", "€39.99"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	policy := &config.UploadPolicy{
+		Columns: map[string][]string{
+			"model":    {"Server Model"},
+			"ram":      {"Memory"},
+			"hdd":      {"Storage"},
+			"location": {"DC"},
+			"price":    {"Monthly Price"},
+		},
+	}
+	uc := New(&mockCatalogRepository{}, policy, testLookups)
+	_, err = uc.UploadCatalog(context.Background(), &dto.UploadCatalogCtr{
+		File: &mockFile{bytes.NewReader(excelBuffer.Bytes())},
+	})
+
+	// errors point at the cell of the uploaded sheet
+	var verr *utils.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("UploadCatalog() error = %v, want *utils.ValidationError", err)
+	}
+	expected := []utils.RowError{
+		{Row: 3, Column: "RAM", Cell: "D3", Value: "4GB", Reason: "invalid RAM format"},
+	}
+	if !reflect.DeepEqual(verr.Rows, expected) {
+		t.Errorf("UploadCatalog() errors = %+v, want %+v", verr.Rows, expected)
+	}
+
+	var uploaded []models.ServerCatalog
+	mockRepo := &mockCatalogRepository{
+		uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+			uploaded = append(uploaded, catalogs...)
+			return nil
+		},
+	}
+	excelBuffer, _ = createTestExcelFile([][]string{
+		{"DC", "Server Model", "Comment", "Memory", "Storage", "Monthly Price"},
+		{"AmsterdamAMS-01", "Dell R210-II", "boot SSD", "16GB DDR3", "2x500GBSATA2", "$35.99"},
+	})
+	_, err = New(mockRepo, policy, testLookups).UploadCatalog(context.Background(), &dto.UploadCatalogCtr{
+		File: &mockFile{bytes.NewReader(excelBuffer.Bytes())},
+	})
+	if err != nil {
+		t.Fatalf("UploadCatalog() error = %v", err)
+	}
+	if len(uploaded) != 1 || uploaded[0].Model != "Dell R210-II" || uploaded[0].Location != "AmsterdamAMS-01" ||
+		uploaded[0].RamSize != 16 || uploaded[0].Price != 35.99 {
+		t.Errorf("UploadCatalog() uploaded %+v", uploaded)
+	}
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"github.com/server-catalog/transformer"
+)
+
+// DiffVersions compares two stored catalog versions. When to is 0 the active
+// version is used.
+func (sc *ServerCatalog) DiffVersions(ctx context.Context, from, to uint) (*dto.CatalogDiffResp, error) {
+	if _, err := sc.SCRepo.GetVersion(ctx, from); err != nil {
+		return nil, err
+	}
+	if to == 0 {
+		active, err := sc.SCRepo.GetActiveVersion(ctx)
+		if err != nil {
+			return nil, err
+		}
+		to = active.ID
+	} else if _, err := sc.SCRepo.GetVersion(ctx, to); err != nil {
+		return nil, err
+	}
+
+	old, err := sc.SCRepo.GetVersionServers(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	current, err := sc.SCRepo.GetVersionServers(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := sc.diffCatalogs(old, current)
+	diff.FromVersion = from
+	diff.ToVersion = to
+	return diff, nil
+}
+
+// DiffCatalog validates an uploaded catalog file and compares it against the
+// active version, without storing it.
+func (sc *ServerCatalog) DiffCatalog(ctx context.Context, ctr *dto.UploadCatalogCtr) (*dto.CatalogDiffResp, error) {
+	cf, err := openCatalog(ctr, sc.workbookOptions())
+	if err != nil {
+		return nil, err
+	}
+	defer cf.Close()
+
+	uploaded := make([]models.ServerCatalog, 0)
+	_, _, err = sc.validateCatalog(cf, func(row catalogRow, catalog models.ServerCatalog) {
+		uploaded = append(uploaded, catalog)
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// everything is new while nothing was uploaded yet
+	var fromVersion uint
+	old := []models.ServerCatalog{}
+	active, err := sc.SCRepo.GetActiveVersion(ctx)
+	switch {
+	case errors.Is(err, utils.ErrVersionNotFound):
+	case err != nil:
+		return nil, err
+	default:
+		fromVersion = active.ID
+		if old, err = sc.SCRepo.GetVersionServers(ctx, active.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	diff := sc.diffCatalogs(old, uploaded)
+	diff.FromVersion = fromVersion
+	return diff, nil
+}
+
+// diffCatalogs matches the servers of two catalogs on their natural key first. The
+// servers left are matched on their model and location, those changed their RAM or
+// HDD. Servers are reported in the order of the catalog they were found in, for
+// duplicated servers only the first one counts.
+func (sc *ServerCatalog) diffCatalogs(old, current []models.ServerCatalog) *dto.CatalogDiffResp {
+	old, current = uniqueServers(old), uniqueServers(current)
+
+	oldByKey := make(map[string]int, len(old))
+	for i, server := range old {
+		oldByKey[server.NaturalKey()] = i
+	}
+
+	diff := &dto.CatalogDiffResp{
+		PriceChanges: make([]dto.PriceChangeResp, 0),
+		SpecChanges:  make([]dto.SpecChangeResp, 0),
+	}
+	matched := make([]bool, len(old))
+	var unmatched []models.ServerCatalog
+	for _, server := range current {
+		i, ok := oldByKey[server.NaturalKey()]
+		if !ok {
+			unmatched = append(unmatched, server)
+			continue
+		}
+		matched[i] = true
+		if previous := old[i]; previous.Price != server.Price || previous.Currency != server.Currency {
+			diff.PriceChanges = append(diff.PriceChanges, transformer.TransformPriceChange(previous, server, sc.Lookups))
+		}
+	}
+
+	// old servers left at every model and location, in catalog order
+	bySlot := make(map[string][]int)
+	for i, server := range old {
+		if !matched[i] {
+			bySlot[serverSlot(server)] = append(bySlot[serverSlot(server)], i)
+		}
+	}
+
+	var added, removed []models.ServerCatalog
+	for _, server := range unmatched {
+		slot := serverSlot(server)
+		candidates := bySlot[slot]
+		if len(candidates) == 0 {
+			added = append(added, server)
+			continue
+		}
+		bySlot[slot] = candidates[1:]
+		matched[candidates[0]] = true
+		diff.SpecChanges = append(diff.SpecChanges, transformer.TransformSpecChange(old[candidates[0]], server, sc.Lookups))
+	}
+
+	for i, server := range old {
+		if !matched[i] {
+			removed = append(removed, server)
+		}
+	}
+
+	diff.Added = transformer.TransformServerList(added, sc.Lookups)
+	diff.Removed = transformer.TransformServerList(removed, sc.Lookups)
+	return diff
+}
+
+// uniqueServers drops the servers repeating the natural key of an earlier one
+func uniqueServers(servers []models.ServerCatalog) []models.ServerCatalog {
+	seen := make(map[string]bool, len(servers))
+	unique := make([]models.ServerCatalog, 0, len(servers))
+	for _, server := range servers {
+		if key := server.NaturalKey(); !seen[key] {
+			seen[key] = true
+			unique = append(unique, server)
+		}
+	}
+	return unique
+}
+
+// serverSlot identifies a server by its model and location, the parts of a server
+// that stay the same when its RAM or HDD is changed
+func serverSlot(server models.ServerCatalog) string {
+	return server.Model + "|" + server.Location
+}
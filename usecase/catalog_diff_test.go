@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"reflect"
+	"testing"
+)
+
+func TestServerCatalog_DiffVersions(t *testing.T) {
+	dell := models.ServerCatalog{Model: "Dell R210-II", RamSize: 16, RamType: utils.RAMTypeDDR3, Disks: []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: utils.HDDTypeSATA2}}, Location: "AmsterdamAMS-01", Price: 40, Currency: utils.CurrencyUSD}
+	hp := models.ServerCatalog{Model: "HP DL120G7", RamSize: 4, RamType: utils.RAMTypeDDR3, Disks: []models.ServerDisk{{HDDCount: 4, HDDSize: 1024, HDDType: utils.HDDTypeSATA2}}, Location: "AmsterdamAMS-01", Price: 39.99, Currency: utils.CurrencyEuro}
+	hpFrankfurt := hp
+	hpFrankfurt.Location = "FrankfurtFRA-10"
+	dellCheaper := dell
+	dellCheaper.Price = 30
+
+	mockRepo := &mockCatalogRepository{
+		versions: []models.CatalogVersion{{ID: 1}, {ID: 2, Active: true}},
+		versionServers: map[uint][]models.ServerCatalog{
+			1: {dell, hp, hp},
+			2: {dellCheaper, hpFrankfurt, hpFrankfurt},
+		},
+	}
+	mockRepo.activeVersion = &mockRepo.versions[1]
+	uc := New(mockRepo, testPolicy, testLookups)
+
+	diff, err := uc.DiffVersions(context.Background(), 1, 0)
+	if err != nil {
+		t.Fatalf("DiffVersions() error = %v", err)
+	}
+
+	delta := -25.0
+	expected := &dto.CatalogDiffResp{
+		FromVersion: 1,
+		ToVersion:   2,
+		Added: []dto.ListServerResp{
+			{Model: "HP DL120G7", Ram: "4GBDDR3", HDD: "4x1TBSATA2", Location: "FrankfurtFRA-10", Price: "€39.99"},
+		},
+		Removed: []dto.ListServerResp{
+			{Model: "HP DL120G7", Ram: "4GBDDR3", HDD: "4x1TBSATA2", Location: "AmsterdamAMS-01", Price: "€39.99"},
+		},
+		PriceChanges: []dto.PriceChangeResp{
+			{Model: "Dell R210-II", Ram: "16GBDDR3", HDD: "2x500GBSATA2", Location: "AmsterdamAMS-01",
+				OldPrice: "$40.00", NewPrice: "$30.00", DeltaPercent: &delta},
+		},
+		SpecChanges: []dto.SpecChangeResp{},
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("DiffVersions() = %+v, want %+v", diff, expected)
+	}
+
+	if _, err := uc.DiffVersions(context.Background(), 3, 0); !errors.Is(err, utils.ErrVersionNotFound) {
+		t.Errorf("DiffVersions() error = %v, want %v", err, utils.ErrVersionNotFound)
+	}
+}
+
+func TestServerCatalog_DiffVersions_SpecChanges(t *testing.T) {
+	dell := models.ServerCatalog{Model: "Dell R210-II", RamSize: 16, RamType: utils.RAMTypeDDR3, Disks: []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: utils.HDDTypeSATA2}}, Location: "AmsterdamAMS-01", Price: 40, Currency: utils.CurrencyUSD}
+	dellBigger := dell
+	dellBigger.RamSize = 32
+	// a second configuration of the same model and location stays untouched
+	dellSSD := dell
+	dellSSD.Disks = []models.ServerDisk{{HDDCount: 2, HDDSize: 120, HDDType: utils.HDDTypeSSD}}
+	dellSSD.Price = 55
+
+	mockRepo := &mockCatalogRepository{
+		versions: []models.CatalogVersion{{ID: 1}, {ID: 2, Active: true}},
+		versionServers: map[uint][]models.ServerCatalog{
+			1: {dell, dellSSD},
+			2: {dellSSD, dellBigger},
+		},
+	}
+	mockRepo.activeVersion = &mockRepo.versions[1]
+	uc := New(mockRepo, testPolicy, testLookups)
+
+	diff, err := uc.DiffVersions(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("DiffVersions() error = %v", err)
+	}
+
+	expected := &dto.CatalogDiffResp{
+		FromVersion:  1,
+		ToVersion:    2,
+		Added:        []dto.ListServerResp{},
+		Removed:      []dto.ListServerResp{},
+		PriceChanges: []dto.PriceChangeResp{},
+		SpecChanges: []dto.SpecChangeResp{
+			{Model: "Dell R210-II", Location: "AmsterdamAMS-01", OldRam: "16GBDDR3", NewRam: "32GBDDR3",
+				OldHDD: "2x500GBSATA2", NewHDD: "2x500GBSATA2", OldPrice: "$40.00", NewPrice: "$40.00"},
+		},
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("DiffVersions() = %+v, want %+v", diff, expected)
+	}
+}
+
+func TestServerCatalog_DiffCatalog(t *testing.T) {
+	excelBuffer, err := createTestExcelFile([][]string{
+		{"Model", "RAM", "HDD", "Location", "Price"},
+		{"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "$35.99"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	// nothing was uploaded yet, so every server is new
+	uc := New(&mockCatalogRepository{}, testPolicy, testLookups)
+	diff, err := uc.DiffCatalog(context.Background(), &dto.UploadCatalogCtr{
+		File: &mockFile{bytes.NewReader(excelBuffer.Bytes())},
+	})
+	if err != nil {
+		t.Fatalf("DiffCatalog() error = %v", err)
+	}
+	if diff.FromVersion != 0 || len(diff.Added) != 1 || len(diff.Removed) != 0 || len(diff.PriceChanges) != 0 {
+		t.Errorf("DiffCatalog() = %+v, want a single added server", diff)
+	}
+}
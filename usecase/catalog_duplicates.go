@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+)
+
+// rowRef locates a catalog row, the sheet name is shared with the catalog file
+type rowRef struct {
+	Sheet string
+	No    int
+}
+
+func (ref rowRef) label() string {
+	return catalogRow{Sheet: ref.Sheet, No: ref.No}.label()
+}
+
+// duplicateCandidate is a row whose natural key hash matches an earlier row
+type duplicateCandidate struct {
+	row     rowRef
+	hash    uint64
+	key     string
+	warning int
+}
+
+// duplicateCheck finds the rows repeating the natural key of an earlier row.
+// It keeps a 64-bit hash and the position of the first row of every key, so its
+// memory doesn't depend on the key length. Natural keys are only kept for the
+// rows whose hash matched, they are compared against the first rows once the
+// whole catalog is validated.
+type duplicateCheck struct {
+	hash       func(key string) uint64
+	first      map[uint64]rowRef
+	candidates []duplicateCandidate
+}
+
+func newDuplicateCheck() *duplicateCheck {
+	return &duplicateCheck{hash: hashKey, first: make(map[uint64]rowRef)}
+}
+
+// hashKey is the FNV-1a hash of a natural key
+func hashKey(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = io.WriteString(h, key)
+	return h.Sum64()
+}
+
+// add records a row and reports whether its hash matches an earlier row. The
+// duplicate warning of such a row goes to index warning of the warnings.
+func (dc *duplicateCheck) add(row catalogRow, key string, warning int) bool {
+	h := dc.hash(key)
+	if _, ok := dc.first[h]; !ok {
+		dc.first[h] = rowRef{Sheet: row.Sheet, No: row.No}
+		return false
+	}
+	dc.candidates = append(dc.candidates, duplicateCandidate{
+		row:     rowRef{Sheet: row.Sheet, No: row.No},
+		hash:    h,
+		key:     key,
+		warning: warning,
+	})
+	return true
+}
+
+// firstRows returns the first rows whose natural key is needed to resolve the candidates
+func (dc *duplicateCheck) firstRows() map[rowRef]string {
+	rows := make(map[rowRef]string)
+	for _, c := range dc.candidates {
+		rows[dc.first[c.hash]] = ""
+	}
+	return rows
+}
+
+// resolve compares the candidates against the natural keys of the first rows and
+// fills in the warnings of the actual duplicates. The placeholders of candidates
+// that only share a hash are removed.
+func (dc *duplicateCheck) resolve(warnings []string, firstKeys map[rowRef]string) []string {
+	type keyRow struct {
+		key string
+		row rowRef
+	}
+	// distinct keys seen per hash, in row order
+	keys := make(map[uint64][]keyRow)
+	for _, c := range dc.candidates {
+		if _, ok := keys[c.hash]; !ok {
+			first := dc.first[c.hash]
+			keys[c.hash] = []keyRow{{key: firstKeys[first], row: first}}
+		}
+
+		var original *keyRow
+		for i := range keys[c.hash] {
+			if keys[c.hash][i].key == c.key {
+				original = &keys[c.hash][i]
+				break
+			}
+		}
+		if original == nil {
+			keys[c.hash] = append(keys[c.hash], keyRow{key: c.key, row: c.row})
+			continue
+		}
+		warnings[c.warning] = fmt.Sprintf("%s duplicates the server of %s", c.row.label(), original.row.label())
+	}
+
+	// drop the placeholders of the hash collisions
+	resolved := warnings[:0]
+	for _, w := range warnings {
+		if w != "" {
+			resolved = append(resolved, w)
+		}
+	}
+	return resolved
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/server-catalog/internal/config"
+	"github.com/server-catalog/internal/dto"
+	"github.com/xuri/excelize/v2"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDuplicateCheck_HashCollisions(t *testing.T) {
+	// every key collides, only the keys tell the rows apart
+	dc := newDuplicateCheck()
+	dc.hash = func(key string) uint64 { return 42 }
+
+	var warnings []string
+	for i, key := range []string{"a", "b", "a", "b", "c", "a"} {
+		if dc.add(catalogRow{No: i + 2}, key, len(warnings)) {
+			warnings = append(warnings, "")
+		}
+	}
+
+	firstKeys := dc.firstRows()
+	firstKeys[rowRef{No: 2}] = "a"
+	warnings = dc.resolve(warnings, firstKeys)
+
+	expected := []string{
+		"row 4 duplicates the server of row 2",
+		"row 5 duplicates the server of row 3",
+		"row 7 duplicates the server of row 2",
+	}
+	if !compareStringSlices(warnings, expected) {
+		t.Errorf("resolve() = %v, want %v", warnings, expected)
+	}
+}
+
+func TestDuplicateCheck_Memory(t *testing.T) {
+	const rows = 100000
+	// natural keys of real catalogs are around this long
+	pad := strings.Repeat("x", 64)
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	dc := newDuplicateCheck()
+	for i := 0; i < rows; i++ {
+		dc.add(catalogRow{No: i + 2}, fmt.Sprintf("Dell R%d|%s", i, pad), 0)
+	}
+
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(dc)
+
+	if len(dc.first) != rows || len(dc.candidates) != 0 {
+		t.Fatalf("duplicateCheck kept %d first rows and %d candidates, want %d and 0", len(dc.first), len(dc.candidates), rows)
+	}
+	// the keys are not retained, only a hash and a row position per row
+	if perRow := (int64(after.HeapAlloc) - int64(before.HeapAlloc)) / rows; perRow > 96 {
+		t.Errorf("duplicateCheck uses %d bytes per row, want at most 96", perRow)
+	}
+}
+
+func TestServerCatalog_ValidateCatalog_LargeUpload(t *testing.T) {
+	const rows = 100000
+
+	var buf bytes.Buffer
+	buf.WriteString("Model,RAM,HDD,Location,Price\n")
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(&buf, "Dell R%d,16GBDDR3,2x500GBSATA2,AmsterdamAMS-01,$35.99\n", i)
+	}
+	// a repriced duplicate of the first and of the last server
+	buf.WriteString("Dell R0,16GBDDR3,2x500GBSATA2,AmsterdamAMS-01,$36.99\n")
+	fmt.Fprintf(&buf, "Dell R%d,16GBDDR3,2x500GBSATA2,AmsterdamAMS-01,$36.99\n", rows-1)
+
+	uc := New(&mockCatalogRepository{}, &config.UploadPolicy{MaxRows: rows + 2, BatchSize: 500}, testLookups).(*ServerCatalog)
+	cf, err := openCatalog(&dto.UploadCatalogCtr{File: &mockFile{bytes.NewReader(buf.Bytes())}, ContentType: "text/csv"}, excelize.Options{})
+	if err != nil {
+		t.Fatalf("openCatalog() error = %v", err)
+	}
+	defer cf.Close()
+
+	var validated int
+	warnings, _, err := uc.validateCatalog(cf, nil, func(n int) { validated = n })
+	if err != nil {
+		t.Fatalf("validateCatalog() error = %v", err)
+	}
+	if validated != rows+2 {
+		t.Errorf("validateCatalog() validated %d rows, want %d", validated, rows+2)
+	}
+
+	expected := []string{
+		fmt.Sprintf("row %d duplicates the server of row 2", rows+2),
+		fmt.Sprintf("row %d duplicates the server of row %d", rows+3, rows+1),
+	}
+	if !compareStringSlices(warnings, expected) {
+		t.Errorf("validateCatalog() warnings = %v, want %v", warnings, expected)
+	}
+}
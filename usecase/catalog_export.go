@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/transformer"
+	"github.com/xuri/excelize/v2"
+)
+
+// ExportServers writes the servers matching the filters of ctr into a workbook
+// that can be uploaded again unchanged. The servers are read in batches so the
+// export never holds the whole catalog in memory, the caller closes the file.
+func (sc *ServerCatalog) ExportServers(ctx context.Context, ctr *dto.ListServersCtr) (*excelize.File, error) {
+	export, err := transformer.NewCatalogExport(sc.Lookups)
+	if err != nil {
+		return nil, fmt.Errorf("usecase:server_catalog:: failed to export servers %v", err)
+	}
+
+	if err := sc.SCRepo.StreamServers(ctx, ctr, sc.batchSize(), export.Add); err != nil {
+		_ = export.Close()
+		return nil, fmt.Errorf("usecase:server_catalog:: failed to export servers %v", err)
+	}
+
+	f, err := export.Finish()
+	if err != nil {
+		return nil, fmt.Errorf("usecase:server_catalog:: failed to export servers %v", err)
+	}
+	return f, nil
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"github.com/server-catalog/internal/config"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/lookup"
+	"github.com/server-catalog/models"
+	"reflect"
+	"testing"
+)
+
+func TestServerCatalog_ExportServers_RoundTrip(t *testing.T) {
+	tables := lookup.Seed
+	tables.Currencies = append(tables.Currencies, models.Currency{ID: 4, Code: "SEK", Type: "Swedish Krona", Symbol: "kr",
+		SymbolPosition: models.SymbolAfter, Decimals: 2, DecimalSeparator: ",", ThousandsSeparator: " "})
+	lookups := lookup.Static(tables)
+
+	excelBuffer, err := createTestExcelFile([][]string{
+		{"Model", "RAM", "HDD", "Location", "Price"},
+		{"Dell R210-IIIntel Xeon E3-1230v2", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "$35.99"},
+		{"HP DL380eG82x Intel Xeon E5-2420", "1TB DDR4-2666 ECC", "2x120GBSSD+8x1536GBSAS", "FrankfurtFRA-10", "€1.299,00"},
+		{"Supermicro SC846Intel Xeon E5-2620", "64GBDDR4", "24x2TBSATA2", "StockholmSTO-01", "12 499,50 kr"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	var uploaded []models.ServerCatalog
+	mockRepo := &mockCatalogRepository{
+		uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+			uploaded = append(uploaded, catalogs...)
+			return nil
+		},
+		getServersFunc: func(ctx context.Context, ctr *dto.ListServersCtr) ([]models.ServerCatalog, error) {
+			return uploaded, nil
+		},
+	}
+	uc := New(mockRepo, &config.UploadPolicy{BatchSize: 2}, lookups)
+	if _, err := uc.UploadCatalog(context.Background(), &dto.UploadCatalogCtr{
+		File: &mockFile{bytes.NewReader(excelBuffer.Bytes())},
+	}); err != nil {
+		t.Fatalf("UploadCatalog() error = %v", err)
+	}
+	original := append([]models.ServerCatalog(nil), uploaded...)
+
+	f, err := uc.ExportServers(context.Background(), &dto.ListServersCtr{})
+	if err != nil {
+		t.Fatalf("ExportServers() error = %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows("Sheet1")
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	if !reflect.DeepEqual(rows[0], catalogColumns) {
+		t.Errorf("ExportServers() header = %v, want %v", rows[0], catalogColumns)
+	}
+	expectedPrices := []string{"$35.99", "€1299.00", "12,499.50 kr"}
+	for i, price := range expectedPrices {
+		if rows[i+1][colPrice] != price {
+			t.Errorf("ExportServers() price of row %d = %q, want %q", i+2, rows[i+1][colPrice], price)
+		}
+	}
+
+	// the exported workbook is uploaded again unchanged
+	exported, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("WriteToBuffer() error = %v", err)
+	}
+	uploaded = nil
+	if _, err := uc.UploadCatalog(context.Background(), &dto.UploadCatalogCtr{
+		File:  &mockFile{bytes.NewReader(exported.Bytes())},
+		Force: true,
+	}); err != nil {
+		t.Fatalf("UploadCatalog() of the export error = %v", err)
+	}
+
+	if len(uploaded) != len(original) {
+		t.Fatalf("UploadCatalog() of the export stored %d servers, want %d", len(uploaded), len(original))
+	}
+	for i := range original {
+		// the mock repository stores the models and locations again, the server itself must not differ
+		uploaded[i].VersionID, uploaded[i].ModelID, uploaded[i].LocationID =
+			original[i].VersionID, original[i].ModelID, original[i].LocationID
+		if !reflect.DeepEqual(uploaded[i], original[i]) {
+			t.Errorf("server %d = %+v, want %+v", i+1, uploaded[i], original[i])
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"github.com/server-catalog/models"
+	"github.com/server-catalog/repository"
+	"regexp"
+	"strings"
+)
+
+// locationPattern splits a location such as "Washington D.C.WDC-01" into its city and datacenter code
+var locationPattern = regexp.MustCompile(`^(.*?)\s*([A-Z]{3}-\d{2,})$`)
+
+// locationSplitter splits the locations of the catalog following the configured countries
+type locationSplitter struct {
+	countries map[string]string
+}
+
+// locationSplitter returns the splitter of the upload policy, countries are left
+// empty without a policy
+func (sc *ServerCatalog) locationSplitter() *locationSplitter {
+	if sc.Policy == nil {
+		return &locationSplitter{}
+	}
+	return &locationSplitter{countries: sc.Policy.Countries}
+}
+
+// split splits a location into its city, datacenter code and country. A location
+// without a datacenter code is kept as the city, the country is empty when the
+// city code of the datacenter isn't configured.
+func (ls *locationSplitter) split(name string) *models.Location {
+	location := &models.Location{Name: name, City: strings.TrimSpace(name)}
+
+	matches := locationPattern.FindStringSubmatch(location.City)
+	if matches == nil {
+		return location
+	}
+	location.City, location.Code = matches[1], matches[2]
+	location.Country = ls.countries[strings.ToLower(location.Code[:3])]
+	return location
+}
+
+// save splits the locations of the batch that weren't stored by this upload yet
+// and sets the location IDs of the servers, ids caches the stored locations by name
+func (ls *locationSplitter) save(ctx context.Context, repo repository.CatalogRepository, ids map[string]uint, batch []models.ServerCatalog) error {
+	pending := make([]*models.Location, 0)
+	names := make([]string, 0)
+	for _, server := range batch {
+		if _, ok := ids[server.Location]; ok {
+			continue
+		}
+		ids[server.Location] = 0
+		pending = append(pending, ls.split(server.Location))
+		names = append(names, server.Location)
+	}
+
+	// the stored location may be written in another case than the uploaded one
+	if err := repo.SaveLocations(ctx, pending); err != nil {
+		return err
+	}
+	for i, location := range pending {
+		ids[names[i]] = location.ID
+	}
+	for i := range batch {
+		batch[i].LocationID = ids[batch[i].Location]
+	}
+	return nil
+}
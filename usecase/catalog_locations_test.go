@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"github.com/server-catalog/internal/config"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testCountries = map[string]string{"ams": "Netherlands", "sin": "Singapore", "wdc": "United States"}
+
+func TestLocationSplitter_Split(t *testing.T) {
+	uc := &ServerCatalog{Policy: &config.UploadPolicy{Countries: testCountries}}
+	splitter := uc.locationSplitter()
+
+	tests := []struct {
+		name     string
+		expected models.Location
+	}{
+		{
+			name:     "AmsterdamAMS-01",
+			expected: models.Location{City: "Amsterdam", Code: "AMS-01", Country: "Netherlands"},
+		},
+		{
+			name:     "Washington D.C.WDC-01",
+			expected: models.Location{City: "Washington D.C.", Code: "WDC-01", Country: "United States"},
+		},
+		{
+			name:     "Singapore SIN-11",
+			expected: models.Location{City: "Singapore", Code: "SIN-11", Country: "Singapore"},
+		},
+		{
+			name:     "Hong KongHKG-10",
+			expected: models.Location{City: "Hong Kong", Code: "HKG-10"},
+		},
+		{
+			name:     "London",
+			expected: models.Location{City: "London"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.expected.Name = tt.name
+			if got := splitter.split(tt.name); *got != tt.expected {
+				t.Errorf("split() = %+v, want %+v", *got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLocationSplitter_NoPolicy(t *testing.T) {
+	// without a policy the location is split but its country is unknown
+	splitter := (&ServerCatalog{}).locationSplitter()
+
+	expected := models.Location{Name: "AmsterdamAMS-01", City: "Amsterdam", Code: "AMS-01"}
+	if got := splitter.split(expected.Name); *got != expected {
+		t.Errorf("split() = %+v, want %+v", *got, expected)
+	}
+}
+
+func TestServerCatalog_BulkUpload_Locations(t *testing.T) {
+	records := []string{
+		`{"model":"HP DL120G7Intel G850","ram_gb":4,"ram_type":"DDR3","disk_count":4,"disk_size_gb":1024,"disk_type":"SATA2","location":"AmsterdamAMS-01","price":39.99,"currency":"EUR"}`,
+		`{"model":"Dell R210Intel Xeon X3440","ram_gb":16,"ram_type":"DDR3","disk_count":2,"disk_size_gb":500,"disk_type":"SATA2","location":"SingaporeSIN-11","price":49.99,"currency":"SGD"}`,
+		`{"model":"HP DL120G7Intel G850","ram_gb":4,"ram_type":"DDR3","disk_count":4,"disk_size_gb":1024,"disk_type":"SATA2","location":"AmsterdamAMS-01","price":44.99,"currency":"EUR"}`,
+	}
+
+	var uploaded []models.ServerCatalog
+	mockRepo := &mockCatalogRepository{
+		uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+			uploaded = append(uploaded, catalogs...)
+			return nil
+		},
+	}
+
+	// the second batch reuses the location stored by the first one
+	uc := New(mockRepo, &config.UploadPolicy{BatchSize: 2, Countries: testCountries}, testLookups)
+	_, err := uc.BulkUpload(context.Background(), &dto.BulkUploadCtr{Body: strings.NewReader(strings.Join(records, "\n"))})
+	if err != nil {
+		t.Fatalf("BulkUpload() error = %v", err)
+	}
+
+	expectedLocations := []models.Location{
+		{ID: 1, Name: "AmsterdamAMS-01", City: "Amsterdam", Code: "AMS-01", Country: "Netherlands"},
+		{ID: 2, Name: "SingaporeSIN-11", City: "Singapore", Code: "SIN-11", Country: "Singapore"},
+	}
+	if !reflect.DeepEqual(mockRepo.locations, expectedLocations) {
+		t.Errorf("BulkUpload() saved locations %+v, want %+v", mockRepo.locations, expectedLocations)
+	}
+
+	locationIDs := []uint{}
+	for _, server := range uploaded {
+		locationIDs = append(locationIDs, server.LocationID)
+	}
+	if !reflect.DeepEqual(locationIDs, []uint{1, 2, 1}) {
+		t.Errorf("BulkUpload() location IDs = %v, want %v", locationIDs, []uint{1, 2, 1})
+	}
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"github.com/server-catalog/internal/config"
+	"github.com/server-catalog/models"
+	"github.com/server-catalog/repository"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// defaultCPUVendors start the CPU part of a model when the upload policy doesn't list any
+var defaultCPUVendors = []string{"Intel", "AMD"}
+
+// modelSplitter splits run-together model strings following the configured model rules
+type modelSplitter struct {
+	// cpu matches the start of the CPU part, the CPU count is a single digit
+	// since it directly follows the chassis name as in R730XD2x Intel
+	cpu     *regexp.Regexp
+	vendors []config.VendorRule
+}
+
+// modelSplitter compiles the model rules of the upload policy, the default rules are
+// used without a policy
+func (sc *ServerCatalog) modelSplitter() *modelSplitter {
+	var rules config.ModelRules
+	if sc.Policy != nil {
+		rules = sc.Policy.Models
+	}
+
+	cpuVendors := rules.CPUVendors
+	if len(cpuVendors) < 1 {
+		cpuVendors = defaultCPUVendors
+	}
+	quoted := make([]string, 0, len(cpuVendors))
+	for _, vendor := range cpuVendors {
+		quoted = append(quoted, regexp.QuoteMeta(vendor))
+	}
+
+	return &modelSplitter{
+		cpu:     regexp.MustCompile(`(?:\d\s*x\s*)?(?:` + strings.Join(quoted, "|") + `)`),
+		vendors: rules.Vendors,
+	}
+}
+
+// split splits a model such as "HP DL120G7Intel G850" or "Dell R730XD2x Intel Xeon E5-2650v4"
+// into its vendor, chassis and CPU. Parts that can't be recognised are left empty.
+func (ms *modelSplitter) split(name string) *models.ServerModel {
+	serverModel := &models.ServerModel{Name: name}
+
+	chassis := strings.TrimSpace(name)
+	if loc := ms.cpu.FindStringIndex(chassis); loc != nil {
+		serverModel.CPU = strings.TrimSpace(chassis[loc[0]:])
+		chassis = strings.TrimSpace(chassis[:loc[0]])
+	}
+
+	serverModel.Vendor, serverModel.Chassis = ms.vendor(chassis)
+	return serverModel
+}
+
+// vendor recognises the vendor of a chassis by its name, e.g. Huawei RH1288v2,
+// or by the prefix of its product line, e.g. DL20G9
+func (ms *modelSplitter) vendor(chassis string) (string, string) {
+	for _, rule := range ms.vendors {
+		if len(chassis) > len(rule.Name) && strings.EqualFold(chassis[:len(rule.Name)], rule.Name) &&
+			chassis[len(rule.Name)] == ' ' {
+			return rule.Name, strings.TrimSpace(chassis[len(rule.Name):])
+		}
+	}
+
+	for _, rule := range ms.vendors {
+		for _, prefix := range rule.Prefixes {
+			rest, ok := strings.CutPrefix(chassis, prefix)
+			if ok && rest != "" && unicode.IsDigit(rune(rest[0])) {
+				return rule.Name, chassis
+			}
+		}
+	}
+	return "", chassis
+}
+
+// save splits the models of the batch that weren't stored by this upload yet
+// and sets the model IDs of the servers, ids caches the stored models by name
+func (ms *modelSplitter) save(ctx context.Context, repo repository.CatalogRepository, ids map[string]uint, batch []models.ServerCatalog) error {
+	pending := make([]*models.ServerModel, 0)
+	for _, server := range batch {
+		if _, ok := ids[server.Model]; ok {
+			continue
+		}
+		ids[server.Model] = 0
+		pending = append(pending, ms.split(server.Model))
+	}
+
+	if err := repo.SaveModels(ctx, pending); err != nil {
+		return err
+	}
+	for _, serverModel := range pending {
+		ids[serverModel.Name] = serverModel.ID
+	}
+	for i := range batch {
+		batch[i].ModelID = ids[batch[i].Model]
+	}
+	return nil
+}
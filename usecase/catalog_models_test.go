@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"github.com/server-catalog/internal/config"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testModelRules = config.ModelRules{
+	CPUVendors: []string{"Intel", "AMD"},
+	Vendors: []config.VendorRule{
+		{Name: "Dell", Prefixes: []string{"R"}},
+		{Name: "HP", Prefixes: []string{"DL", "ML"}},
+		{Name: "Huawei", Prefixes: []string{"RH"}},
+		{Name: "IBM", Prefixes: []string{"X3"}},
+		{Name: "Supermicro", Prefixes: []string{"SC"}},
+	},
+}
+
+func TestModelSplitter_Split(t *testing.T) {
+	uc := &ServerCatalog{Policy: &config.UploadPolicy{Models: testModelRules}}
+	splitter := uc.modelSplitter()
+
+	tests := []struct {
+		name     string
+		expected models.ServerModel
+	}{
+		{
+			name:     "HP DL120G7Intel G850",
+			expected: models.ServerModel{Vendor: "HP", Chassis: "DL120G7", CPU: "Intel G850"},
+		},
+		{
+			name:     "Dell R210Intel Xeon X3440",
+			expected: models.ServerModel{Vendor: "Dell", Chassis: "R210", CPU: "Intel Xeon X3440"},
+		},
+		{
+			name:     "Dell R210-IIIntel Xeon E3-1230v2",
+			expected: models.ServerModel{Vendor: "Dell", Chassis: "R210-II", CPU: "Intel Xeon E3-1230v2"},
+		},
+		{
+			name:     "Dell R730XD2x Intel Xeon E5-2650v4",
+			expected: models.ServerModel{Vendor: "Dell", Chassis: "R730XD", CPU: "2x Intel Xeon E5-2650v4"},
+		},
+		{
+			name:     "HP DL120G91x Intel E5-1650v3",
+			expected: models.ServerModel{Vendor: "HP", Chassis: "DL120G9", CPU: "1x Intel E5-1650v3"},
+		},
+		{
+			name:     "Dell R9304x Intel Xeon E7-4850v3",
+			expected: models.ServerModel{Vendor: "Dell", Chassis: "R930", CPU: "4x Intel Xeon E7-4850v3"},
+		},
+		{
+			name:     "Huawei RH1288v22x Intel Xeon E5-2650",
+			expected: models.ServerModel{Vendor: "Huawei", Chassis: "RH1288v2", CPU: "2x Intel Xeon E5-2650"},
+		},
+		{
+			name:     "RH2288v32x Intel Xeon E5-2650V4",
+			expected: models.ServerModel{Vendor: "Huawei", Chassis: "RH2288v3", CPU: "2x Intel Xeon E5-2650V4"},
+		},
+		{
+			name:     "DL20G9Intel Xeon E3-1270v5",
+			expected: models.ServerModel{Vendor: "HP", Chassis: "DL20G9", CPU: "Intel Xeon E3-1270v5"},
+		},
+		{
+			name:     "Supermicro SC813MTQIntel Xeon E5-1650v2",
+			expected: models.ServerModel{Vendor: "Supermicro", Chassis: "SC813MTQ", CPU: "Intel Xeon E5-1650v2"},
+		},
+		{
+			name:     "Lenovo SR6502x AMD EPYC 7302",
+			expected: models.ServerModel{Chassis: "Lenovo SR650", CPU: "2x AMD EPYC 7302"},
+		},
+		{
+			name:     "Custom build",
+			expected: models.ServerModel{Chassis: "Custom build"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.expected.Name = tt.name
+			if got := splitter.split(tt.name); *got != tt.expected {
+				t.Errorf("split() = %+v, want %+v", *got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestModelSplitter_NoPolicy(t *testing.T) {
+	// without a policy the CPU is split by the default CPU vendors and no vendor is recognised
+	splitter := (&ServerCatalog{}).modelSplitter()
+
+	expected := models.ServerModel{Name: "HP DL120G7Intel G850", Chassis: "HP DL120G7", CPU: "Intel G850"}
+	if got := splitter.split(expected.Name); *got != expected {
+		t.Errorf("split() = %+v, want %+v", *got, expected)
+	}
+}
+
+func TestServerCatalog_BulkUpload_Models(t *testing.T) {
+	records := []string{
+		`{"model":"HP DL120G7Intel G850","ram_gb":4,"ram_type":"DDR3","disk_count":4,"disk_size_gb":1024,"disk_type":"SATA2","location":"AmsterdamAMS-01","price":39.99,"currency":"EUR"}`,
+		`{"model":"Dell R210Intel Xeon X3440","ram_gb":16,"ram_type":"DDR3","disk_count":2,"disk_size_gb":500,"disk_type":"SATA2","location":"AmsterdamAMS-01","price":49.99,"currency":"EUR"}`,
+		`{"model":"HP DL120G7Intel G850","ram_gb":4,"ram_type":"DDR3","disk_count":4,"disk_size_gb":1024,"disk_type":"SATA2","location":"DallasDAL-10","price":39.99,"currency":"USD"}`,
+	}
+
+	var uploaded []models.ServerCatalog
+	mockRepo := &mockCatalogRepository{
+		uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+			uploaded = append(uploaded, catalogs...)
+			return nil
+		},
+	}
+
+	// the second batch reuses the model stored by the first one
+	uc := New(mockRepo, &config.UploadPolicy{BatchSize: 2, Models: testModelRules}, testLookups)
+	_, err := uc.BulkUpload(context.Background(), &dto.BulkUploadCtr{Body: strings.NewReader(strings.Join(records, "\n"))})
+	if err != nil {
+		t.Fatalf("BulkUpload() error = %v", err)
+	}
+
+	expectedModels := []models.ServerModel{
+		{ID: 1, Name: "HP DL120G7Intel G850", Vendor: "HP", Chassis: "DL120G7", CPU: "Intel G850"},
+		{ID: 2, Name: "Dell R210Intel Xeon X3440", Vendor: "Dell", Chassis: "R210", CPU: "Intel Xeon X3440"},
+	}
+	if !reflect.DeepEqual(mockRepo.serverModels, expectedModels) {
+		t.Errorf("BulkUpload() saved models %+v, want %+v", mockRepo.serverModels, expectedModels)
+	}
+
+	modelIDs := []uint{}
+	for _, server := range uploaded {
+		modelIDs = append(modelIDs, server.ModelID)
+	}
+	if !reflect.DeepEqual(modelIDs, []uint{1, 2, 1}) {
+		t.Errorf("BulkUpload() model IDs = %v, want %v", modelIDs, []uint{1, 2, 1})
+	}
+}
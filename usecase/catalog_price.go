@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pricePattern splits a price into the currency in front of the amount, the amount
+// and the currency behind it. Spaces and apostrophes may group the digits.
+var pricePattern = regexp.MustCompile(`^([^\d\s\x{00A0}\x{202F}.,'\-]*)[\s\x{00A0}\x{202F}]*(\d(?:[\d.,'\s\x{00A0}\x{202F}]*\d)?)[\s\x{00A0}\x{202F}]*([^\d\s\x{00A0}\x{202F}.,'\-]*)$`)
+
+// digitGroupSeparators are the characters besides dots and commas used to group digits
+const digitGroupSeparators = " '\u00a0\u202f"
+
+// parsePrice reads the amount and currency of a price such as $35.99, "39,99 €",
+// "EUR 1.299,00", "USD 45" or 45.00$. The currency is returned as given, either
+// a symbol or an ISO 4217 code. raw is the unformatted value of an XLSX cell: the
+// amount of a numeric cell is taken from it since its formatted text may be rounded.
+func parsePrice(price, raw string) (float64, string, error) {
+	price = strings.TrimSpace(price)
+	matches := pricePattern.FindStringSubmatch(price)
+	if len(matches) != 4 {
+		return 0, "", fmt.Errorf("invalid price format: %s", price)
+	}
+
+	prefix, number, suffix := matches[1], matches[2], matches[3]
+	if prefix != "" && suffix != "" {
+		return 0, "", fmt.Errorf("ambiguous price: %s has a currency on both sides", price)
+	}
+	currency := prefix + suffix
+	if currency == "" {
+		return 0, "", fmt.Errorf("price has no currency: %s", price)
+	}
+
+	if raw != "" && raw != price {
+		if amount, err := strconv.ParseFloat(raw, 64); err == nil {
+			return amount, currency, nil
+		}
+	}
+
+	amount, err := parseAmount(number)
+	if err != nil {
+		return 0, "", err
+	}
+	return amount, currency, nil
+}
+
+// parseAmount reads an amount using either a dot or a comma as decimal separator.
+// When both appear the last one is the decimal separator. A single separator
+// followed by exactly three digits, as in 1,299 or 1.299, reads differently
+// depending on the locale and is rejected.
+func parseAmount(number string) (float64, error) {
+	dot, comma := strings.LastIndexByte(number, '.'), strings.LastIndexByte(number, ',')
+
+	var decimal, thousands byte
+	switch {
+	case dot >= 0 && comma >= 0:
+		decimal, thousands = '.', ','
+		if comma > dot {
+			decimal, thousands = ',', '.'
+		}
+	case dot >= 0 || comma >= 0:
+		sep, at := byte('.'), dot
+		if comma >= 0 {
+			sep, at = ',', comma
+		}
+		integer, fraction := number[:at], number[at+1:]
+		switch {
+		case strings.Count(number, string(sep)) > 1:
+			thousands = sep
+		case len(fraction) != 3 || integer == "0" || len(integer) > 3:
+			decimal = sep
+		default:
+			return 0, fmt.Errorf("ambiguous price: %s uses %q as decimal or thousands separator", number, sep)
+		}
+	}
+
+	integer, fraction := number, ""
+	if decimal != 0 {
+		at := strings.LastIndexByte(number, decimal)
+		integer, fraction = number[:at], number[at+1:]
+		if strings.ContainsAny(fraction, ".,"+digitGroupSeparators) || strings.IndexByte(integer, decimal) >= 0 {
+			return 0, fmt.Errorf("invalid amount format: %s", number)
+		}
+	}
+
+	groups := strings.Split(strings.Map(func(r rune) rune {
+		if r == rune(thousands) || strings.ContainsRune(digitGroupSeparators, r) {
+			return ' '
+		}
+		return r
+	}, integer), " ")
+	for i, group := range groups {
+		// thousands separators group the digits by three
+		if group == "" || (len(groups) > 1 && (len(group) > 3 || (i > 0 && len(group) != 3))) {
+			return 0, fmt.Errorf("invalid amount format: %s", number)
+		}
+	}
+
+	normalized := strings.Join(groups, "")
+	if fraction != "" {
+		normalized += "." + fraction
+	}
+	amount, err := strconv.ParseFloat(normalized, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount format: %s", number)
+	}
+	return amount, nil
+}
+
+// priceCurrencyID resolves the currency of a price given by its ISO 4217 code or its symbol
+func (sc *ServerCatalog) priceCurrencyID(currency string) (int, error) {
+	if isCurrencyCode(currency) {
+		return sc.Lookups.CurrencyIDByCode(currency)
+	}
+	return sc.Lookups.CurrencyIDBySymbol(currency)
+}
+
+// isCurrencyCode reports whether the currency is written as a three letter code
+func isCurrencyCode(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, r := range currency {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/xuri/excelize/v2"
+	"testing"
+)
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		name             string
+		price            string
+		raw              string
+		expectedAmount   float64
+		expectedCurrency string
+		expectedError    error
+	}{
+		{name: "leading symbol", price: "$35.99", expectedAmount: 35.99, expectedCurrency: "$"},
+		{name: "leading multi character symbol", price: "S$39.99", expectedAmount: 39.99, expectedCurrency: "S$"},
+		{name: "trailing symbol", price: "45.00$", expectedAmount: 45, expectedCurrency: "$"},
+		{name: "comma decimal and trailing symbol", price: "39,99 €", expectedAmount: 39.99, expectedCurrency: "€"},
+		{name: "code with thousands and comma decimal", price: "EUR 1.299,00", expectedAmount: 1299, expectedCurrency: "EUR"},
+		{name: "code without decimals", price: "USD 45", expectedAmount: 45, expectedCurrency: "USD"},
+		{name: "thousands and dot decimal", price: "€1,299.50", expectedAmount: 1299.5, expectedCurrency: "€"},
+		{name: "repeated thousands separator", price: "$1,299,000", expectedAmount: 1299000, expectedCurrency: "$"},
+		{name: "space grouped digits", price: "1 299,00 EUR", expectedAmount: 1299, expectedCurrency: "EUR"},
+		{name: "no-break space grouped digits", price: "1 299,00 €", expectedAmount: 1299, expectedCurrency: "€"},
+		{name: "three decimals below one", price: "$0.500", expectedAmount: 0.5, expectedCurrency: "$"},
+		{name: "numeric cell with currency format", price: "$1,300", raw: "1299.5", expectedAmount: 1299.5, expectedCurrency: "$"},
+		{name: "ambiguous comma", price: "$1,299", expectedError: errors.New(`ambiguous price: 1,299 uses ',' as decimal or thousands separator`)},
+		{name: "ambiguous dot", price: "1.299 €", expectedError: errors.New(`ambiguous price: 1.299 uses '.' as decimal or thousands separator`)},
+		{name: "currency on both sides", price: "$45 USD", expectedError: errors.New("ambiguous price: $45 USD has a currency on both sides")},
+		{name: "missing currency", price: "45.00", expectedError: errors.New("price has no currency: 45.00")},
+		{name: "numeric cell without currency format", price: "1299.5", raw: "1299.5", expectedError: errors.New("price has no currency: 1299.5")},
+		{name: "invalid grouping", price: "$12,99,000", expectedError: errors.New("invalid amount format: 12,99,000")},
+		{name: "separator after decimal", price: "€1.299,00.5", expectedError: errors.New("invalid amount format: 1.299,00.5")},
+		{name: "empty price", price: "", expectedError: errors.New("invalid price format: ")},
+		{name: "negative price", price: "-$5", expectedError: errors.New("invalid price format: -$5")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, currency, err := parsePrice(tt.price, tt.raw)
+			if (err != nil && tt.expectedError == nil) ||
+				(err == nil && tt.expectedError != nil) ||
+				(err != nil && tt.expectedError != nil && err.Error() != tt.expectedError.Error()) {
+				t.Fatalf("parsePrice() error = %v, want %v", err, tt.expectedError)
+			}
+			if amount != tt.expectedAmount || currency != tt.expectedCurrency {
+				t.Errorf("parsePrice() = %v, %q, want %v, %q", amount, currency, tt.expectedAmount, tt.expectedCurrency)
+			}
+		})
+	}
+}
+
+func TestServerCatalog_PreviewCatalog_NumericPrices(t *testing.T) {
+	f := excelize.NewFile()
+	rows := [][]interface{}{
+		{"Model", "RAM", "HDD", "Location", "Price"},
+		{"Dell R210-II", "16GBDDR3", "2x500GBSATA2", "AmsterdamAMS-01", 39.99},
+		{"HP DL120G7", "4GBDDR3", "4x1TBSATA2", "SingaporeSIN-11", 1299.5},
+		{"HP DL380eG8", "8GBDDR3", "8x2TBSATA2", "DallasDAL-10", 45.5},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow("Sheet1", cell, &row); err != nil {
+			t.Fatalf("Failed to write row: %v", err)
+		}
+	}
+	formats := map[string]string{
+		"E2": `#,##0.00 [$€-407]`,
+		// rounded to whole dollars, the raw value keeps the cents
+		"E3": `"S$"#,##0`,
+		// a number format without a currency
+		"E4": `#,##0.00`,
+	}
+	for cell, format := range formats {
+		style, err := f.NewStyle(&excelize.Style{CustomNumFmt: &format})
+		if err != nil {
+			t.Fatalf("Failed to create style: %v", err)
+		}
+		if err := f.SetCellStyle("Sheet1", cell, cell, style); err != nil {
+			t.Fatalf("Failed to set style: %v", err)
+		}
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	_, err = New(&mockCatalogRepository{}, testPolicy, testLookups).PreviewCatalog(context.Background(), &dto.UploadCatalogCtr{
+		File: &mockFile{bytes.NewReader(buf.Bytes())},
+	})
+	var verr *utils.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("PreviewCatalog() error = %v, want *utils.ValidationError", err)
+	}
+	expected := []utils.RowError{
+		{Row: 4, Column: "Price", Cell: "E4", Value: "45.50", Reason: "price has no currency: 45.50"},
+	}
+	if len(verr.Rows) != 1 || verr.Rows[0] != expected[0] {
+		t.Fatalf("PreviewCatalog() errors = %+v, want %+v", verr.Rows, expected)
+	}
+
+	// without the row lacking a currency the others are read exactly
+	if err := f.RemoveRow("Sheet1", 4); err != nil {
+		t.Fatalf("Failed to remove row: %v", err)
+	}
+	buf, err = f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+	preview, err := New(&mockCatalogRepository{}, testPolicy, testLookups).PreviewCatalog(context.Background(), &dto.UploadCatalogCtr{
+		File: &mockFile{bytes.NewReader(buf.Bytes())},
+	})
+	if err != nil {
+		t.Fatalf("PreviewCatalog() error = %v", err)
+	}
+	if len(preview.Rows) != 2 {
+		t.Fatalf("PreviewCatalog() returned %d rows, want 2", len(preview.Rows))
+	}
+	if preview.Rows[0].Price != 39.99 || preview.Rows[0].CurrencyID != utils.CurrencyEuro {
+		t.Errorf("PreviewCatalog() row[0] price = %v %d, want 39.99 %d", preview.Rows[0].Price, preview.Rows[0].CurrencyID, utils.CurrencyEuro)
+	}
+	if preview.Rows[1].Price != 1299.5 || preview.Rows[1].CurrencyID != utils.CurrencySGD {
+		t.Errorf("PreviewCatalog() row[1] price = %v %d, want 1299.5 %d", preview.Rows[1].Price, preview.Rows[1].CurrencyID, utils.CurrencySGD)
+	}
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"github.com/xuri/excelize/v2"
+	"reflect"
+	"testing"
+)
+
+// createTestWorkbook creates a workbook with one sheet per entry, in the given order
+func createTestWorkbook(t *testing.T, sheets []string, data map[string][][]string) *mockFile {
+	f := excelize.NewFile()
+	for i, sheet := range sheets {
+		if i == 0 {
+			if err := f.SetSheetName("Sheet1", sheet); err != nil {
+				t.Fatalf("Failed to rename sheet: %v", err)
+			}
+		} else if _, err := f.NewSheet(sheet); err != nil {
+			t.Fatalf("Failed to create sheet: %v", err)
+		}
+		for j, row := range data[sheet] {
+			cell, _ := excelize.CoordinatesToCellName(1, j+1)
+			if err := f.SetSheetRow(sheet, cell, &row); err != nil {
+				t.Fatalf("Failed to write row: %v", err)
+			}
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+	return &mockFile{bytes.NewReader(buf.Bytes())}
+}
+
+func TestServerCatalog_UploadCatalog_Sheets(t *testing.T) {
+	header := []string{"Model", "RAM", "HDD", "Location", "Price"}
+	sheets := []string{"Notes", "EU", "US", "APAC"}
+	data := map[string][][]string{
+		"Notes": {{"Prices exclude VAT"}},
+		"EU": {
+			header,
+			{"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "€35.99"},
+			{"HP DL120G7", "4GBDDR3", "4x1TBSATA2", "FrankfurtFRA-10", "€39.99"},
+		},
+		"US": {
+			header,
+			{"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "DallasDAL-10", "$35.99"},
+		},
+		"APAC": {},
+	}
+
+	tests := []struct {
+		name           string
+		sheets         []string
+		expectedModels []string
+		expectedSheets []dto.SheetResult
+		expectedError  error
+	}{
+		{
+			name:          "first sheet by default",
+			expectedError: errors.New("usecase:server_catalog:invalid XLSX columns: missing Model (accepted: Model); missing RAM (accepted: RAM); missing HDD (accepted: HDD); missing Location (accepted: Location); missing Price (accepted: Price)"),
+		},
+		{
+			name:           "named sheet",
+			sheets:         []string{"US"},
+			expectedModels: []string{"Dell R210-II"},
+			expectedSheets: []dto.SheetResult{{Name: "US", Servers: 1}},
+		},
+		{
+			name:           "list of sheets",
+			sheets:         []string{"US", "EU", "US"},
+			expectedModels: []string{"Dell R210-II", "Dell R210-II", "HP DL120G7"},
+			expectedSheets: []dto.SheetResult{{Name: "US", Servers: 1}, {Name: "EU", Servers: 2}},
+		},
+		{
+			name:           "every sheet with a catalog header",
+			sheets:         []string{dto.AllSheets},
+			expectedModels: []string{"Dell R210-II", "HP DL120G7", "Dell R210-II"},
+			expectedSheets: []dto.SheetResult{
+				{Name: "Notes", Skipped: true, Reason: "invalid XLSX columns: missing Model (accepted: Model); missing RAM (accepted: RAM); missing HDD (accepted: HDD); missing Location (accepted: Location); missing Price (accepted: Price)"},
+				{Name: "EU", Servers: 2},
+				{Name: "US", Servers: 1},
+				{Name: "APAC", Skipped: true, Reason: "no data in the sheet"},
+			},
+		},
+		{
+			name:          "sheet without catalog header",
+			sheets:        []string{"EU", "Notes"},
+			expectedError: errors.New(`usecase:server_catalog:invalid XLSX columns: missing Model (accepted: Model); missing RAM (accepted: RAM); missing HDD (accepted: HDD); missing Location (accepted: Location); missing Price (accepted: Price) in sheet "Notes"`),
+		},
+		{
+			name:          "unknown sheet",
+			sheets:        []string{"LATAM"},
+			expectedError: errors.New(`usecase:server_catalog:sheet "LATAM" not found`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uploaded []string
+			mockRepo := &mockCatalogRepository{
+				uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+					for _, catalog := range catalogs {
+						uploaded = append(uploaded, catalog.Model)
+					}
+					return nil
+				},
+			}
+
+			resp, err := New(mockRepo, testPolicy, testLookups).UploadCatalog(context.Background(), &dto.UploadCatalogCtr{
+				File:   createTestWorkbook(t, sheets, data),
+				Sheets: tt.sheets,
+			})
+			if (err != nil && tt.expectedError == nil) ||
+				(err == nil && tt.expectedError != nil) ||
+				(err != nil && tt.expectedError != nil && err.Error() != tt.expectedError.Error()) {
+				t.Fatalf("UploadCatalog() error = %v, want %v", err, tt.expectedError)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(uploaded, tt.expectedModels) {
+				t.Errorf("UploadCatalog() uploaded %v, want %v", uploaded, tt.expectedModels)
+			}
+			if !reflect.DeepEqual(resp.Sheets, tt.expectedSheets) {
+				t.Errorf("UploadCatalog() sheets = %+v, want %+v", resp.Sheets, tt.expectedSheets)
+			}
+		})
+	}
+}
+
+func TestServerCatalog_PreviewCatalog_Sheets(t *testing.T) {
+	header := []string{"Model", "RAM", "HDD", "Location", "Price"}
+	file := createTestWorkbook(t, []string{"EU", "US"}, map[string][][]string{
+		"EU": {header, {"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "€35.99"}},
+		"US": {header, {"Dell R210-II", "16GB", "2x500GBSATA2", "DallasDAL-10", "$35.99"}},
+	})
+
+	_, err := New(&mockCatalogRepository{}, testPolicy, testLookups).PreviewCatalog(context.Background(), &dto.UploadCatalogCtr{
+		File:   file,
+		Sheets: []string{dto.AllSheets},
+	})
+
+	// errors are keyed by sheet and cell
+	var verr *utils.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("PreviewCatalog() error = %v, want *utils.ValidationError", err)
+	}
+	expected := utils.Errors{
+		"US!B2": {`sheet US, row 2, column RAM, value "16GB": invalid RAM format`},
+	}
+	if !reflect.DeepEqual(verr.Errors(), expected) {
+		t.Errorf("PreviewCatalog() errors = %v, want %v", verr.Errors(), expected)
+	}
+}
@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/server-catalog/internal/dto"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"mime"
+	"slices"
+	"strings"
+)
+
+// Supported catalog file formats
+const (
+	formatXLSX = "xlsx"
+	formatCSV  = "csv"
+	formatTSV  = "tsv"
+)
+
+// utf8BOM is written in front of CSV files by some spreadsheet applications
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// zipMagic are the first bytes of every XLSX (zip) file
+var zipMagic = []byte("PK\x03\x04")
+
+// detectFormat determines the catalog file format. The leading bytes of the file
+// take precedence since clients often send a generic content type.
+func detectFormat(contentType string, head []byte) string {
+	if bytes.HasPrefix(head, zipMagic) {
+		return formatXLSX
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/tab-separated-values":
+		return formatTSV
+	case "text/csv", "application/csv":
+		return formatCSV
+	}
+
+	firstLine := head
+	if i := bytes.IndexByte(head, '\n'); i >= 0 {
+		firstLine = head[:i]
+	}
+	if bytes.IndexByte(firstLine, '\t') >= 0 {
+		return formatTSV
+	}
+	return formatCSV
+}
+
+// rowIterator walks the rows of a catalog sheet one at a time
+type rowIterator interface {
+	// Next advances to the next row and reports whether there is one
+	Next() bool
+	// Row returns the cells of the current row
+	Row() ([]string, error)
+	// Raw returns the unformatted cells of the current row, nil when the format has no number formats
+	Raw() ([]string, error)
+	// Err returns the error that stopped the iteration, if any
+	Err() error
+	Close() error
+}
+
+// catalogFile is an uploaded catalog opened for reading. Its rows can be
+// iterated more than once without loading the whole sheet into memory.
+type catalogFile struct {
+	format string
+	src    io.ReaderAt
+	size   int64
+	book   *excelize.File
+	// sheets are the selected sheets of a workbook, CSV and TSV files have a single unnamed sheet
+	sheets []string
+	// named is set when the sheets were selected explicitly, rows are then reported with their sheet
+	named bool
+	// matchAll is set when every sheet with a catalog header is imported, the others are skipped
+	matchAll bool
+}
+
+// namedFile is implemented by uploads backed by a file on disk, such as a spooled upload
+type namedFile interface {
+	Name() string
+}
+
+// openCatalog detects the format of the uploaded file and prepares it for reading,
+// workbooks are opened with the unzip limits in opts
+func openCatalog(ctr *dto.UploadCatalogCtr, opts excelize.Options) (*catalogFile, error) {
+	size, err := ctr.File.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("usecase:server_catalog:failed to read file")
+	}
+
+	head := make([]byte, min(size, 512))
+	if _, err := ctr.File.ReadAt(head, 0); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("usecase:server_catalog:failed to read file")
+	}
+
+	cf := &catalogFile{
+		format:   detectFormat(ctr.ContentType, head),
+		src:      ctr.File,
+		size:     size,
+		sheets:   []string{""},
+		matchAll: len(ctr.Sheets) == 1 && ctr.Sheets[0] == dto.AllSheets,
+	}
+	if cf.format != formatXLSX {
+		if len(ctr.Sheets) > 0 && !cf.matchAll {
+			return nil, fmt.Errorf("usecase:server_catalog:sheets can only be selected in XLSX files")
+		}
+		cf.matchAll = false
+		return cf, nil
+	}
+
+	// excelize reads the compressed workbook into memory, the unzip limits bound
+	// what it extracts from it. Worksheets above UnzipXMLSizeLimit are extracted
+	// to temporary files and streamed.
+	if f, ok := ctr.File.(namedFile); ok {
+		cf.book, err = excelize.OpenFile(f.Name(), opts)
+	} else {
+		cf.book, err = excelize.OpenReader(io.NewSectionReader(ctr.File, 0, size), opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("usecase:server_catalog:invalid XLSX file: %v", err)
+	}
+
+	if err := cf.selectSheets(ctr.Sheets); err != nil {
+		cf.book.Close()
+		return nil, err
+	}
+	return cf, nil
+}
+
+// selectSheets picks the workbook sheets to import: the first one by default,
+// every sheet for AllSheets, otherwise the named ones
+func (cf *catalogFile) selectSheets(names []string) error {
+	switch {
+	case len(names) == 0:
+		first := cf.book.GetSheetName(0)
+		if first == "" {
+			return fmt.Errorf("usecase:server_catalog:no sheet found")
+		}
+		cf.sheets = []string{first}
+		return nil
+	case cf.matchAll:
+		cf.sheets = cf.book.GetSheetList()
+	default:
+		cf.sheets = make([]string, 0, len(names))
+		for _, name := range names {
+			if idx, _ := cf.book.GetSheetIndex(name); idx < 0 {
+				return fmt.Errorf("usecase:server_catalog:sheet %q not found", name)
+			}
+			if !slices.Contains(cf.sheets, name) {
+				cf.sheets = append(cf.sheets, name)
+			}
+		}
+	}
+	cf.named = true
+	return nil
+}
+
+// rows returns a new iterator positioned before the first row of the sheet
+func (cf *catalogFile) rows(sheet string) (rowIterator, error) {
+	if cf.format == formatXLSX {
+		rows, err := cf.book.Rows(sheet)
+		if err != nil {
+			return nil, fmt.Errorf("usecase:server_catalog:no data in the sheet")
+		}
+		// excelize returns a row either formatted or raw, the raw values are
+		// read by a second iterator that moves in step with the first one
+		raw, err := cf.book.Rows(sheet)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("usecase:server_catalog:no data in the sheet")
+		}
+		return &xlsxRows{rows: rows, raw: raw}, nil
+	}
+
+	r := bufio.NewReader(io.NewSectionReader(cf.src, 0, cf.size))
+	if bom, _ := r.Peek(len(utf8BOM)); bytes.Equal(bom, utf8BOM) {
+		_, _ = r.Discard(len(utf8BOM))
+	}
+
+	cr := csv.NewReader(r)
+	if cf.format == formatTSV {
+		cr.Comma = '\t'
+	}
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.ReuseRecord = true
+	return &delimitedRows{reader: cr, format: cf.format}, nil
+}
+
+// Close releases the resources held by the parsed workbook
+func (cf *catalogFile) Close() error {
+	if cf.book != nil {
+		return cf.book.Close()
+	}
+	return nil
+}
+
+// xlsxRows iterates a worksheet through excelize's streaming row reader
+type xlsxRows struct {
+	rows *excelize.Rows
+	raw  *excelize.Rows
+}
+
+func (x *xlsxRows) Next() bool {
+	x.raw.Next()
+	return x.rows.Next()
+}
+
+func (x *xlsxRows) Row() ([]string, error) {
+	return x.rows.Columns()
+}
+
+func (x *xlsxRows) Raw() ([]string, error) {
+	return x.raw.Columns(excelize.Options{RawCellValue: true})
+}
+
+func (x *xlsxRows) Err() error {
+	if err := x.rows.Error(); err != nil {
+		return err
+	}
+	return x.raw.Error()
+}
+
+func (x *xlsxRows) Close() error {
+	return errors.Join(x.rows.Close(), x.raw.Close())
+}
+
+// delimitedRows iterates the records of a CSV or TSV file
+type delimitedRows struct {
+	reader *csv.Reader
+	format string
+	record []string
+	err    error
+}
+
+func (d *delimitedRows) Next() bool {
+	if d.err != nil {
+		return false
+	}
+	d.record, d.err = d.reader.Read()
+	return d.err == nil
+}
+
+func (d *delimitedRows) Row() ([]string, error) {
+	return d.record, nil
+}
+
+func (d *delimitedRows) Raw() ([]string, error) {
+	return nil, nil
+}
+
+func (d *delimitedRows) Err() error {
+	if d.err == nil || errors.Is(d.err, io.EOF) {
+		return nil
+	}
+	return fmt.Errorf("usecase:server_catalog:invalid %s file: %v", strings.ToUpper(d.format), d.err)
+}
+
+func (d *delimitedRows) Close() error {
+	return nil
+}
@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"github.com/server-catalog/internal/config"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/models"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		head        []byte
+		expected    string
+	}{
+		{
+			name:        "xlsx magic bytes",
+			contentType: "application/octet-stream",
+			head:        []byte("PK\x03\x04rest"),
+			expected:    formatXLSX,
+		},
+		{
+			name:        "xlsx magic bytes win over content type",
+			contentType: "text/csv",
+			head:        []byte("PK\x03\x04rest"),
+			expected:    formatXLSX,
+		},
+		{
+			name:        "csv content type",
+			contentType: "text/csv; charset=utf-8",
+			head:        []byte("Model,RAM,HDD,Location,Price\n"),
+			expected:    formatCSV,
+		},
+		{
+			name:        "tsv content type",
+			contentType: "text/tab-separated-values",
+			head:        []byte("Model\tRAM\tHDD\tLocation\tPrice\n"),
+			expected:    formatTSV,
+		},
+		{
+			name:        "sniffed tsv",
+			contentType: "application/octet-stream",
+			head:        []byte("Model\tRAM\tHDD\tLocation\tPrice\nDell,R210\t16GBDDR3"),
+			expected:    formatTSV,
+		},
+		{
+			name:        "sniffed csv",
+			contentType: "",
+			head:        []byte("Model,RAM,HDD,Location,Price\nDell\tR210,16GBDDR3"),
+			expected:    formatCSV,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectFormat(tt.contentType, tt.head); got != tt.expected {
+				t.Errorf("detectFormat() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestServerCatalog_UploadCatalog_Delimited(t *testing.T) {
+	rows := [][]string{
+		{"Model", "RAM", "HDD", "Location", "Price"},
+		{"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "$35.99"},
+		{"HP DL120G7Intel G850", "4GBDDR3", "4x1TBSATA2", "Washington D.C.WDC-01", "€39.99"},
+	}
+
+	excelBuffer, err := createTestExcelFile(rows)
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		data        []byte
+	}{
+		{
+			name:        "xlsx",
+			contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			data:        excelBuffer.Bytes(),
+		},
+		{
+			name:        "csv",
+			contentType: "text/csv",
+			data: []byte("Model,RAM,HDD,Location,Price\n" +
+				"Dell R210-II,16GB DDR3,2x500GBSATA2,AmsterdamAMS-01,$35.99\n" +
+				"HP DL120G7Intel G850,4GBDDR3,4x1TBSATA2,Washington D.C.WDC-01,€39.99\n"),
+		},
+		{
+			name:        "csv with BOM and CRLF",
+			contentType: "application/octet-stream",
+			data: []byte("\xef\xbb\xbfModel,RAM,HDD,Location,Price\r\n" +
+				"Dell R210-II,16GB DDR3,2x500GBSATA2,AmsterdamAMS-01,$35.99\r\n" +
+				"\"HP DL120G7Intel G850\",4GBDDR3,4x1TBSATA2,\"Washington D.C.WDC-01\",€39.99\r\n"),
+		},
+		{
+			name:        "tsv",
+			contentType: "application/octet-stream",
+			data: []byte("Model\tRAM\tHDD\tLocation\tPrice\n" +
+				"Dell R210-II\t16GB DDR3\t2x500GBSATA2\tAmsterdamAMS-01\t$35.99\n" +
+				"HP DL120G7Intel G850\t4GBDDR3\t4x1TBSATA2\tWashington D.C.WDC-01\t€39.99\n"),
+		},
+	}
+
+	var expected []models.ServerCatalog
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uploaded []models.ServerCatalog
+			mockRepo := &mockCatalogRepository{
+				uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+					uploaded = catalogs
+					return nil
+				},
+			}
+
+			uc := New(mockRepo, testPolicy, testLookups)
+			_, err := uc.UploadCatalog(context.Background(), &dto.UploadCatalogCtr{
+				File:        &mockFile{bytes.NewReader(tt.data)},
+				ContentType: tt.contentType,
+			})
+			if err != nil {
+				t.Fatalf("UploadCatalog() error = %v", err)
+			}
+			if len(uploaded) != len(rows)-1 {
+				t.Fatalf("UploadCatalog() uploaded %d rows, want %d", len(uploaded), len(rows)-1)
+			}
+
+			// every format must produce exactly what the XLSX upload produced
+			if expected == nil {
+				expected = uploaded
+			} else if !reflect.DeepEqual(uploaded, expected) {
+				t.Errorf("UploadCatalog() uploaded %+v, want %+v", uploaded, expected)
+			}
+		})
+	}
+}
+
+func TestServerCatalog_UploadCatalog_InvalidCSVHeader(t *testing.T) {
+	uc := New(&mockCatalogRepository{}, testPolicy, testLookups)
+	_, err := uc.UploadCatalog(context.Background(), &dto.UploadCatalogCtr{
+		File: &mockFile{bytes.NewReader([]byte("Model,Memory,HDD,Location,Price\n" +
+			"Dell R210-II,16GB DDR3,2x500GBSATA2,AmsterdamAMS-01,$35.99\n"))},
+		ContentType: "text/csv",
+	})
+	if err == nil || err.Error() != "usecase:server_catalog:invalid CSV columns: missing RAM (accepted: RAM)" {
+		t.Errorf("UploadCatalog() error = %v, want invalid CSV columns", err)
+	}
+}
+
+func TestServerCatalog_UploadCatalog_SpooledWorkbook(t *testing.T) {
+	excelBuffer, err := createTestExcelFile([][]string{
+		{"Model", "RAM", "HDD", "Location", "Price"},
+		{"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "$35.99"},
+		{"HP DL120G7", "4GBDDR3", "4x1TBSATA2", "AmsterdamAMS-01", "€39.99"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "servers.xlsx")
+	if err := os.WriteFile(path, excelBuffer.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		policy           *config.UploadPolicy
+		expectedInserted int
+		expectedErr      string
+	}{
+		{
+			name:             "default limits",
+			policy:           testPolicy,
+			expectedInserted: 2,
+		},
+		{
+			name:        "workbook above the unzip size limit",
+			policy:      &config.UploadPolicy{MaxRows: 1000, BatchSize: 500, UnzipSizeLimit: 1024, UnzipXMLSizeLimit: 512},
+			expectedErr: "usecase:server_catalog:invalid XLSX file: unzip size exceeds the 1024 bytes limit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			uc := New(&mockCatalogRepository{
+				uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+					return nil
+				},
+			}, tt.policy, testLookups)
+			resp, err := uc.UploadCatalog(context.Background(), &dto.UploadCatalogCtr{File: f})
+			if tt.expectedErr != "" {
+				if err == nil || err.Error() != tt.expectedErr {
+					t.Errorf("UploadCatalog() error = %v, want %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UploadCatalog() error = %v", err)
+			}
+			if resp.Inserted != tt.expectedInserted {
+				t.Errorf("UploadCatalog() inserted = %v, want %v", resp.Inserted, tt.expectedInserted)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"github.com/server-catalog/transformer"
+	"hash"
+	"io"
+	"log"
+	"time"
+)
+
+// startUpload records an upload before it is stored. An upload whose checksum
+// matches the last successful upload is recorded as a duplicate and rejected
+// with utils.ErrDuplicateUpload, unless force is set.
+func (sc *ServerCatalog) startUpload(ctx context.Context, upload *models.Upload, force bool) (*models.Upload, error) {
+	last, err := sc.SCRepo.GetLastSuccessfulUpload(ctx)
+	if err != nil && !errors.Is(err, utils.ErrUploadNotFound) {
+		return nil, uploadFailed(err)
+	}
+	duplicate := last != nil && last.Checksum == upload.Checksum
+
+	upload.Outcome = models.UploadOutcomeRunning
+	upload.Forced = duplicate && force
+	upload.StartedAt = time.Now()
+	var rejected error
+	if duplicate && !force {
+		rejected = fmt.Errorf("usecase:server_catalog:: file matches upload %d, force the upload to store it again: %w",
+			last.ID, utils.ErrDuplicateUpload)
+		upload.Outcome = models.UploadOutcomeDuplicate
+		upload.Error = rejected.Error()
+		upload.EndedAt = &upload.StartedAt
+	}
+
+	if err := sc.SCRepo.CreateUpload(ctx, upload); err != nil {
+		return nil, uploadFailed(err)
+	}
+	if rejected != nil {
+		return nil, rejected
+	}
+	return upload, nil
+}
+
+// finishUpload records how an upload ended, the record is stored even when ctx was canceled
+func (sc *ServerCatalog) finishUpload(ctx context.Context, upload *models.Upload, resp *dto.UploadCatalogResp, err error) {
+	now := time.Now()
+	upload.EndedAt = &now
+	if err != nil {
+		upload.Outcome = models.UploadOutcomeFailed
+		upload.Error = err.Error()
+	} else {
+		version := resp.Version
+		upload.Outcome = models.UploadOutcomeSucceeded
+		upload.Rows = resp.Inserted + resp.Updated + resp.Unchanged
+		upload.Inserted = resp.Inserted
+		upload.Updated = resp.Updated
+		upload.Unchanged = resp.Unchanged
+		upload.Deleted = resp.Deleted
+		upload.VersionID = &version
+		resp.Upload = upload.ID
+	}
+
+	if err := sc.SCRepo.UpdateUpload(context.WithoutCancel(ctx), upload); err != nil {
+		log.Println(err)
+	}
+}
+
+// GetUploads returns a page of the upload history, newest first
+func (sc *ServerCatalog) GetUploads(ctx context.Context, page *utils.Page) ([]dto.UploadResp, error) {
+	uploads, err := sc.SCRepo.GetUploads(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return transformer.TransformUploads(uploads), nil
+}
+
+// checksum returns the hex encoded SHA-256 of the catalog file
+func (cf *catalogFile) checksum() (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(cf.src, 0, cf.size)); err != nil {
+		return "", fmt.Errorf("usecase:server_catalog:failed to read file")
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// digestReader hashes and counts the bytes read through it
+type digestReader struct {
+	r    io.Reader
+	hash hash.Hash
+	size int64
+}
+
+func newDigestReader(r io.Reader) *digestReader {
+	return &digestReader{r: r, hash: sha256.New()}
+}
+
+func (dr *digestReader) Read(p []byte) (int, error) {
+	n, err := dr.r.Read(p)
+	dr.hash.Write(p[:n])
+	dr.size += int64(n)
+	return n, err
+}
+
+// checksum returns the hex encoded SHA-256 of the bytes read so far
+func (dr *digestReader) checksum() string {
+	return hex.EncodeToString(dr.hash.Sum(nil))
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestServerCatalog_UploadCatalog_Provenance(t *testing.T) {
+	excelBuffer, err := createTestExcelFile([][]string{
+		{"Model", "RAM", "HDD", "Location", "Price"},
+		{"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "$35.99"},
+		{"HP DL120G7", "4GBDDR3", "4x1TBSATA2", "AmsterdamAMS-01", "€39.99"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+	content := excelBuffer.Bytes()
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	mockRepo := &mockCatalogRepository{
+		uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+			return nil
+		},
+	}
+	uc := New(mockRepo, testPolicy, testLookups)
+	upload := func(force bool) (*dto.UploadCatalogResp, error) {
+		return uc.UploadCatalog(context.Background(), &dto.UploadCatalogCtr{
+			File:     &mockFile{bytes.NewReader(content)},
+			Filename: "servers.xlsx",
+			Uploader: "app-key:1a2b3c4d5e6f",
+			Force:    force,
+		})
+	}
+
+	resp, err := upload(false)
+	if err != nil {
+		t.Fatalf("UploadCatalog() error = %v", err)
+	}
+	if resp.Upload != 1 {
+		t.Errorf("UploadCatalog() upload = %d, want 1", resp.Upload)
+	}
+
+	// the same file again is rejected, unless it is forced
+	if _, err := upload(false); !errors.Is(err, utils.ErrDuplicateUpload) {
+		t.Fatalf("UploadCatalog() error = %v, want %v", err, utils.ErrDuplicateUpload)
+	}
+	resp, err = upload(true)
+	if err != nil {
+		t.Fatalf("UploadCatalog() error = %v", err)
+	}
+	if resp.Upload != 3 {
+		t.Errorf("UploadCatalog() upload = %d, want 3", resp.Upload)
+	}
+
+	expected := []struct {
+		outcome string
+		forced  bool
+		rows    int
+		version uint
+	}{
+		{outcome: models.UploadOutcomeSucceeded, rows: 2, version: 1},
+		{outcome: models.UploadOutcomeDuplicate},
+		{outcome: models.UploadOutcomeSucceeded, forced: true, rows: 2, version: 2},
+	}
+	if len(mockRepo.uploads) != len(expected) {
+		t.Fatalf("UploadCatalog() recorded %d uploads, want %d", len(mockRepo.uploads), len(expected))
+	}
+	for i, want := range expected {
+		got := mockRepo.uploads[i]
+		if got.Source != models.UploadSourceFile || got.Filename != "servers.xlsx" || got.Size != int64(len(content)) ||
+			got.Checksum != checksum || got.Uploader != "app-key:1a2b3c4d5e6f" || got.Mode != dto.UploadModeAppend {
+			t.Errorf("upload %d provenance = %+v", i+1, got)
+		}
+		if got.Outcome != want.outcome || got.Forced != want.forced || got.Rows != want.rows {
+			t.Errorf("upload %d = %s (forced %v, %d rows), want %s (forced %v, %d rows)",
+				i+1, got.Outcome, got.Forced, got.Rows, want.outcome, want.forced, want.rows)
+		}
+		if (want.version == 0) != (got.VersionID == nil) || (got.VersionID != nil && *got.VersionID != want.version) {
+			t.Errorf("upload %d version = %v, want %d", i+1, got.VersionID, want.version)
+		}
+		if got.EndedAt == nil {
+			t.Errorf("upload %d has no end time", i+1)
+		}
+	}
+}
+
+func TestServerCatalog_BulkUpload_Provenance(t *testing.T) {
+	body := `{"model":"HP DL120G7Intel G850","ram_gb":4,"ram_type":"DDR3","disk_count":4,"disk_size_gb":1024,"disk_type":"SATA2","location":"AmsterdamAMS-01","price":39.99,"currency":"EUR"}`
+
+	uploadErr := errors.New("connection lost")
+	mockRepo := &mockCatalogRepository{
+		uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+			return uploadErr
+		},
+	}
+	uc := New(mockRepo, testPolicy, testLookups)
+
+	// a failed upload is recorded but doesn't count as the last successful one
+	_, err := uc.BulkUpload(context.Background(), &dto.BulkUploadCtr{Body: strings.NewReader(body)})
+	if err == nil {
+		t.Fatal("BulkUpload() error = nil, want an error")
+	}
+	mockRepo.uploadFunc = func(ctx context.Context, catalogs []models.ServerCatalog) error {
+		return nil
+	}
+	if _, err := uc.BulkUpload(context.Background(), &dto.BulkUploadCtr{Body: strings.NewReader(body)}); err != nil {
+		t.Fatalf("BulkUpload() error = %v", err)
+	}
+	if _, err := uc.BulkUpload(context.Background(), &dto.BulkUploadCtr{Body: strings.NewReader(body)}); !errors.Is(err, utils.ErrDuplicateUpload) {
+		t.Fatalf("BulkUpload() error = %v, want %v", err, utils.ErrDuplicateUpload)
+	}
+
+	outcomes := make([]string, 0, len(mockRepo.uploads))
+	for _, upload := range mockRepo.uploads {
+		outcomes = append(outcomes, upload.Outcome)
+		if upload.Source != models.UploadSourceBulk || upload.Size != int64(len(body)) {
+			t.Errorf("upload %d = %s of %d bytes, want %s of %d bytes",
+				upload.ID, upload.Source, upload.Size, models.UploadSourceBulk, len(body))
+		}
+	}
+	expected := []string{models.UploadOutcomeFailed, models.UploadOutcomeSucceeded, models.UploadOutcomeDuplicate}
+	if !reflect.DeepEqual(outcomes, expected) {
+		t.Errorf("BulkUpload() outcomes = %v, want %v", outcomes, expected)
+	}
+}
@@ -3,11 +3,37 @@ package usecase
 import (
 	"context"
 	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/xuri/excelize/v2"
 )
 
 type CatalogUseCase interface {
-	UploadCatalog(ctx context.Context, ctr *dto.UploadCatalogCtr) error
-	GetLocations(ctx context.Context) ([]string, error)
+	UploadCatalog(ctx context.Context, ctr *dto.UploadCatalogCtr) (*dto.UploadCatalogResp, error)
+	PreviewCatalog(ctx context.Context, ctr *dto.UploadCatalogCtr) (*dto.CatalogPreviewResp, error)
+	BulkUpload(ctx context.Context, ctr *dto.BulkUploadCtr) (*dto.UploadCatalogResp, error)
+	GetLocations(ctx context.Context) ([]dto.LocationResp, error)
 	GetHDDTypes(ctx context.Context) ([]string, error)
 	GetListOfServers(ctx context.Context, ctr *dto.ListServersCtr) ([]dto.ListServerResp, error)
+	ExportServers(ctx context.Context, ctr *dto.ListServersCtr) (*excelize.File, error)
+	GetUploads(ctx context.Context, page *utils.Page) ([]dto.UploadResp, error)
+	GetVersions(ctx context.Context) ([]dto.CatalogVersionResp, error)
+	ActivateVersion(ctx context.Context, id uint) (*dto.CatalogVersionResp, error)
+	DiffVersions(ctx context.Context, from, to uint) (*dto.CatalogDiffResp, error)
+	DiffCatalog(ctx context.Context, ctr *dto.UploadCatalogCtr) (*dto.CatalogDiffResp, error)
+}
+
+type JobUseCase interface {
+	Start(ctx context.Context) error
+	SubmitUpload(ctx context.Context, ctr *dto.UploadCatalogCtr) (*dto.UploadJobResp, error)
+	GetJob(ctx context.Context, id uint) (*dto.UploadJobResp, error)
+	Shutdown(ctx context.Context) error
+}
+
+type LookupUseCase interface {
+	GetHDDTypes(ctx context.Context) ([]dto.SpecTypeResp, error)
+	CreateHDDType(ctx context.Context, ctr *dto.SpecTypeCtr) (*dto.SpecTypeResp, error)
+	GetRAMTypes(ctx context.Context) ([]dto.SpecTypeResp, error)
+	CreateRAMType(ctx context.Context, ctr *dto.SpecTypeCtr) (*dto.SpecTypeResp, error)
+	GetCurrencies(ctx context.Context) ([]dto.CurrencyResp, error)
+	CreateCurrency(ctx context.Context, ctr *dto.CurrencyCtr) (*dto.CurrencyResp, error)
 }
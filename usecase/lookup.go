@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/lookup"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"github.com/server-catalog/repository"
+	"github.com/server-catalog/transformer"
+	"regexp"
+	"strings"
+)
+
+var (
+	// hddTypePattern matches the types the HDD column can be parsed with, e.g. 2x1TBNVMe
+	hddTypePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]{0,31}$`)
+	// ramTypePattern matches the types the RAM column can be parsed with, e.g. 64GBDDR5-4800
+	ramTypePattern = regexp.MustCompile(`^[A-Za-z]+\d*$`)
+	codePattern    = regexp.MustCompile(`^[A-Za-z]{3}$`)
+	// symbolPattern matches the symbols a price can be parsed with
+	symbolPattern = regexp.MustCompile(`^[^\d\s\x{00A0}\x{202F}.,'\-]{1,8}$`)
+)
+
+// maxCurrencyDecimals is the number of decimal places prices are stored with
+const maxCurrencyDecimals = 2
+
+// Lookup manages the HDD types, RAM types and currencies of the lookup registry.
+// The registry is refreshed after every change so it is used right away.
+type Lookup struct {
+	Repo    repository.LookupRepository
+	Lookups *lookup.Registry
+}
+
+func NewLookup(repo repository.LookupRepository, lookups *lookup.Registry) LookupUseCase {
+	return &Lookup{Repo: repo, Lookups: lookups}
+}
+
+func (l *Lookup) GetHDDTypes(ctx context.Context) ([]dto.SpecTypeResp, error) {
+	return transformer.TransformHDDSpecs(l.Lookups.Tables().HDDSpecs), nil
+}
+
+func (l *Lookup) CreateHDDType(ctx context.Context, ctr *dto.SpecTypeCtr) (*dto.SpecTypeResp, error) {
+	typ := strings.TrimSpace(ctr.Type)
+	if !hddTypePattern.MatchString(typ) {
+		return nil, fmt.Errorf("usecase:lookup:: %w: HDD type must be letters followed by letters or digits", utils.ErrInvalidLookup)
+	}
+	if err := l.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := l.Lookups.HDDTypeID(typ); err == nil {
+		return nil, fmt.Errorf("usecase:lookup:: %w: HDD type %s", utils.ErrLookupExists, typ)
+	}
+
+	spec := &models.HDDSpec{Type: typ}
+	if err := l.Repo.CreateHDDSpec(ctx, spec); err != nil {
+		return nil, err
+	}
+	if err := l.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return &transformer.TransformHDDSpecs([]models.HDDSpec{*spec})[0], nil
+}
+
+func (l *Lookup) GetRAMTypes(ctx context.Context) ([]dto.SpecTypeResp, error) {
+	return transformer.TransformRAMSpecs(l.Lookups.Tables().RAMSpecs), nil
+}
+
+func (l *Lookup) CreateRAMType(ctx context.Context, ctr *dto.SpecTypeCtr) (*dto.SpecTypeResp, error) {
+	typ := strings.TrimSpace(ctr.Type)
+	if len(typ) > 32 || !ramTypePattern.MatchString(typ) {
+		return nil, fmt.Errorf("usecase:lookup:: %w: RAM type must be letters followed by digits", utils.ErrInvalidLookup)
+	}
+	if err := l.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := l.Lookups.RAMTypeID(typ); err == nil {
+		return nil, fmt.Errorf("usecase:lookup:: %w: RAM type %s", utils.ErrLookupExists, typ)
+	}
+
+	spec := &models.RamSpec{Type: typ}
+	if err := l.Repo.CreateRAMSpec(ctx, spec); err != nil {
+		return nil, err
+	}
+	if err := l.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return &transformer.TransformRAMSpecs([]models.RamSpec{*spec})[0], nil
+}
+
+func (l *Lookup) GetCurrencies(ctx context.Context) ([]dto.CurrencyResp, error) {
+	return transformer.TransformCurrencies(l.Lookups.Tables().Currencies), nil
+}
+
+func (l *Lookup) CreateCurrency(ctx context.Context, ctr *dto.CurrencyCtr) (*dto.CurrencyResp, error) {
+	currency := &models.Currency{
+		Code:               strings.ToUpper(strings.TrimSpace(ctr.Code)),
+		Type:               strings.TrimSpace(ctr.Name),
+		Symbol:             strings.TrimSpace(ctr.Symbol),
+		SymbolPosition:     strings.ToLower(strings.TrimSpace(ctr.SymbolPosition)),
+		Decimals:           2,
+		DecimalSeparator:   ctr.DecimalSeparator,
+		ThousandsSeparator: ctr.ThousandsSeparator,
+	}
+	if currency.SymbolPosition == "" {
+		currency.SymbolPosition = models.SymbolBefore
+	}
+	if ctr.Decimals != nil {
+		currency.Decimals = *ctr.Decimals
+	}
+	if currency.DecimalSeparator == "" {
+		currency.DecimalSeparator = "."
+	}
+
+	switch {
+	case !codePattern.MatchString(currency.Code):
+		return nil, fmt.Errorf("usecase:lookup:: %w: currency code must be an ISO 4217 code", utils.ErrInvalidLookup)
+	case currency.Type == "" || len(currency.Type) > 16:
+		return nil, fmt.Errorf("usecase:lookup:: %w: currency name must have 1 to 16 characters", utils.ErrInvalidLookup)
+	case !symbolPattern.MatchString(currency.Symbol) || isCurrencyCode(currency.Symbol):
+		// three letters are read as a currency code
+		return nil, fmt.Errorf("usecase:lookup:: %w: currency symbol must have up to 8 characters without digits, separators or spaces and can't be a currency code", utils.ErrInvalidLookup)
+	case currency.SymbolPosition != models.SymbolBefore && currency.SymbolPosition != models.SymbolAfter:
+		return nil, fmt.Errorf("usecase:lookup:: %w: symbol position must be %s or %s", utils.ErrInvalidLookup, models.SymbolBefore, models.SymbolAfter)
+	case currency.Decimals < 0 || currency.Decimals > maxCurrencyDecimals:
+		return nil, fmt.Errorf("usecase:lookup:: %w: decimals must be between 0 and %d", utils.ErrInvalidLookup, maxCurrencyDecimals)
+	case currency.DecimalSeparator != "." && currency.DecimalSeparator != ",":
+		return nil, fmt.Errorf("usecase:lookup:: %w: decimal separator must be . or ,", utils.ErrInvalidLookup)
+	case currency.ThousandsSeparator != "" && (currency.ThousandsSeparator == currency.DecimalSeparator ||
+		!strings.Contains(".,"+digitGroupSeparators, currency.ThousandsSeparator) || len([]rune(currency.ThousandsSeparator)) != 1):
+		// rendered prices can be uploaded again
+		return nil, fmt.Errorf("usecase:lookup:: %w: thousands separator must be one of . , ' or a space and differ from the decimal separator", utils.ErrInvalidLookup)
+	}
+	if err := l.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := l.Lookups.CurrencyIDByCode(currency.Code); err == nil {
+		return nil, fmt.Errorf("usecase:lookup:: %w: currency code %s", utils.ErrLookupExists, currency.Code)
+	}
+	if _, err := l.Lookups.CurrencyIDBySymbol(currency.Symbol); err == nil {
+		return nil, fmt.Errorf("usecase:lookup:: %w: currency symbol %s", utils.ErrLookupExists, currency.Symbol)
+	}
+
+	if err := l.Repo.CreateCurrency(ctx, currency); err != nil {
+		return nil, err
+	}
+	if err := l.refresh(ctx); err != nil {
+		return nil, err
+	}
+	resp := transformer.TransformCurrency(*currency)
+	return &resp, nil
+}
+
+// refresh reloads the registry so changes made through other instances are seen
+func (l *Lookup) refresh(ctx context.Context) error {
+	if err := l.Lookups.Refresh(ctx); err != nil {
+		return fmt.Errorf("usecase:lookup:: failed to load lookups %v", err)
+	}
+	return nil
+}
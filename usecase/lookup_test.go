@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/lookup"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// mockLookupRepository keeps the lookup tables in memory, starting from the seeded rows
+type mockLookupRepository struct {
+	tables lookup.Tables
+}
+
+func newMockLookupRepository() *mockLookupRepository {
+	seed := lookup.Seed
+	return &mockLookupRepository{tables: lookup.Tables{
+		HDDSpecs:   append([]models.HDDSpec{}, seed.HDDSpecs...),
+		RAMSpecs:   append([]models.RamSpec{}, seed.RAMSpecs...),
+		Currencies: append([]models.Currency{}, seed.Currencies...),
+	}}
+}
+
+func (m *mockLookupRepository) GetHDDSpecs(ctx context.Context) ([]models.HDDSpec, error) {
+	return m.tables.HDDSpecs, nil
+}
+
+func (m *mockLookupRepository) GetRAMSpecs(ctx context.Context) ([]models.RamSpec, error) {
+	return m.tables.RAMSpecs, nil
+}
+
+func (m *mockLookupRepository) GetCurrencies(ctx context.Context) ([]models.Currency, error) {
+	return m.tables.Currencies, nil
+}
+
+func (m *mockLookupRepository) CreateHDDSpec(ctx context.Context, spec *models.HDDSpec) error {
+	spec.ID = uint(len(m.tables.HDDSpecs) + 1)
+	m.tables.HDDSpecs = append(m.tables.HDDSpecs, *spec)
+	return nil
+}
+
+func (m *mockLookupRepository) CreateRAMSpec(ctx context.Context, spec *models.RamSpec) error {
+	spec.ID = uint(len(m.tables.RAMSpecs) + 1)
+	m.tables.RAMSpecs = append(m.tables.RAMSpecs, *spec)
+	return nil
+}
+
+func (m *mockLookupRepository) CreateCurrency(ctx context.Context, currency *models.Currency) error {
+	currency.ID = uint(len(m.tables.Currencies) + 1)
+	m.tables.Currencies = append(m.tables.Currencies, *currency)
+	return nil
+}
+
+func TestLookup_Create(t *testing.T) {
+	tests := []struct {
+		name          string
+		create        func(uc LookupUseCase) (interface{}, error)
+		expected      interface{}
+		expectedError error
+	}{
+		{
+			name: "hdd type",
+			create: func(uc LookupUseCase) (interface{}, error) {
+				return uc.CreateHDDType(context.Background(), &dto.SpecTypeCtr{Type: " NVMe "})
+			},
+			expected: &dto.SpecTypeResp{ID: 4, Type: "NVMe"},
+		},
+		{
+			name: "known hdd type",
+			create: func(uc LookupUseCase) (interface{}, error) {
+				return uc.CreateHDDType(context.Background(), &dto.SpecTypeCtr{Type: "ssd"})
+			},
+			expectedError: utils.ErrLookupExists,
+		},
+		{
+			name: "hdd type that can't be parsed",
+			create: func(uc LookupUseCase) (interface{}, error) {
+				return uc.CreateHDDType(context.Background(), &dto.SpecTypeCtr{Type: "SATA-3"})
+			},
+			expectedError: utils.ErrInvalidLookup,
+		},
+		{
+			name: "ram type",
+			create: func(uc LookupUseCase) (interface{}, error) {
+				return uc.CreateRAMType(context.Background(), &dto.SpecTypeCtr{Type: "DDR6"})
+			},
+			expected: &dto.SpecTypeResp{ID: 4, Type: "DDR6"},
+		},
+		{
+			name: "ram type that can't be parsed",
+			create: func(uc LookupUseCase) (interface{}, error) {
+				return uc.CreateRAMType(context.Background(), &dto.SpecTypeCtr{Type: "5DDR"})
+			},
+			expectedError: utils.ErrInvalidLookup,
+		},
+		{
+			name: "currency",
+			create: func(uc LookupUseCase) (interface{}, error) {
+				return uc.CreateCurrency(context.Background(), &dto.CurrencyCtr{Code: "gbp", Name: "Pound Sterling", Symbol: "£", ThousandsSeparator: ","})
+			},
+			expected: &dto.CurrencyResp{ID: 4, Code: "GBP", Name: "Pound Sterling", Symbol: "£",
+				SymbolPosition: "before", Decimals: 2, DecimalSeparator: ".", ThousandsSeparator: ",", Example: "£1,234.50"},
+		},
+		{
+			name: "currency without decimals",
+			create: func(uc LookupUseCase) (interface{}, error) {
+				return uc.CreateCurrency(context.Background(), &dto.CurrencyCtr{Code: "JPY", Name: "Yen", Symbol: "¥", Decimals: &[]int{0}[0]})
+			},
+			expected: &dto.CurrencyResp{ID: 4, Code: "JPY", Name: "Yen", Symbol: "¥",
+				SymbolPosition: "before", Decimals: 0, DecimalSeparator: ".", Example: "¥1234"},
+		},
+		{
+			name: "currency with the symbol after the amount",
+			create: func(uc LookupUseCase) (interface{}, error) {
+				return uc.CreateCurrency(context.Background(), &dto.CurrencyCtr{Code: "SEK", Name: "Swedish Krona", Symbol: "kr",
+					SymbolPosition: "after", DecimalSeparator: ",", ThousandsSeparator: " "})
+			},
+			expected: &dto.CurrencyResp{ID: 4, Code: "SEK", Name: "Swedish Krona", Symbol: "kr",
+				SymbolPosition: "after", Decimals: 2, DecimalSeparator: ",", ThousandsSeparator: " ", Example: "1 234,50 kr"},
+		},
+		{
+			name: "currency with more decimals than prices are stored with",
+			create: func(uc LookupUseCase) (interface{}, error) {
+				return uc.CreateCurrency(context.Background(), &dto.CurrencyCtr{Code: "BHD", Name: "Bahraini Dinar", Symbol: "BD", Decimals: &[]int{3}[0]})
+			},
+			expectedError: utils.ErrInvalidLookup,
+		},
+		{
+			name: "currency with the same decimal and thousands separator",
+			create: func(uc LookupUseCase) (interface{}, error) {
+				return uc.CreateCurrency(context.Background(), &dto.CurrencyCtr{Code: "GBP", Name: "Pound Sterling", Symbol: "£", ThousandsSeparator: "."})
+			},
+			expectedError: utils.ErrInvalidLookup,
+		},
+		{
+			name: "currency with a known symbol",
+			create: func(uc LookupUseCase) (interface{}, error) {
+				return uc.CreateCurrency(context.Background(), &dto.CurrencyCtr{Code: "CAD", Name: "Canadian Dollar", Symbol: "$"})
+			},
+			expectedError: utils.ErrLookupExists,
+		},
+		{
+			name: "currency symbol read as a code",
+			create: func(uc LookupUseCase) (interface{}, error) {
+				return uc.CreateCurrency(context.Background(), &dto.CurrencyCtr{Code: "CHF", Name: "Swiss Franc", Symbol: "Fr."})
+			},
+			expectedError: utils.ErrInvalidLookup,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockLookupRepository()
+			got, err := tt.create(NewLookup(repo, lookup.New(repo)))
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Errorf("create error = %v, want %v", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("create error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("create = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLookup_CreateHDDType_Upload(t *testing.T) {
+	repo := newMockLookupRepository()
+	lookups := lookup.New(repo)
+	if err := lookups.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	var uploaded []models.ServerCatalog
+	catalog := New(&mockCatalogRepository{
+		uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+			uploaded = append(uploaded, catalogs...)
+			return nil
+		},
+	}, testPolicy, lookups)
+	record := `{"model":"Dell R7615AMD EPYC 9354P","ram_gb":128,"ram_type":"DDR5","disk_count":2,"disk_size_gb":1024,"disk_type":"NVMe","location":"AmsterdamAMS-01","price":249,"currency":"EUR"}`
+
+	// the type is unknown until it is added
+	_, err := catalog.BulkUpload(context.Background(), &dto.BulkUploadCtr{Body: strings.NewReader(record)})
+	var verr *utils.ValidationError
+	if !errors.As(err, &verr) || verr.Rows[0].Reason != "unknown HDD type: NVME" {
+		t.Fatalf("BulkUpload() error = %v, want an unknown HDD type", err)
+	}
+
+	if _, err := NewLookup(repo, lookups).CreateHDDType(context.Background(), &dto.SpecTypeCtr{Type: "NVMe"}); err != nil {
+		t.Fatalf("CreateHDDType() error = %v", err)
+	}
+	if _, err := catalog.BulkUpload(context.Background(), &dto.BulkUploadCtr{Body: strings.NewReader(record)}); err != nil {
+		t.Fatalf("BulkUpload() error = %v", err)
+	}
+	if len(uploaded) != 1 || uploaded[0].Disks[0].HDDType != 4 {
+		t.Errorf("BulkUpload() uploaded %+v, want the disks of HDD type 4", uploaded)
+	}
+}
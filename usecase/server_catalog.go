@@ -1,204 +1,679 @@
 package usecase
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"github.com/server-catalog/internal/config"
 	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/lookup"
 	"github.com/server-catalog/internal/utils"
 	"github.com/server-catalog/models"
 	"github.com/server-catalog/repository"
 	"github.com/server-catalog/transformer"
 	"github.com/xuri/excelize/v2"
-	"io"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
 type ServerCatalog struct {
-	SCRepo repository.CatalogRepository
+	SCRepo  repository.CatalogRepository
+	Policy  *config.UploadPolicy
+	Lookups *lookup.Registry
 }
 
-func New(scr repository.CatalogRepository) CatalogUseCase {
-	return &ServerCatalog{SCRepo: scr}
+func New(scr repository.CatalogRepository, policy *config.UploadPolicy, lookups *lookup.Registry) CatalogUseCase {
+	return &ServerCatalog{SCRepo: scr, Policy: policy, Lookups: lookups}
 }
 
-func (sc *ServerCatalog) UploadCatalog(ctx context.Context, ctr *dto.UploadCatalogCtr) error {
-	data, err := io.ReadAll(ctr.File)
+// UploadCatalog validates the whole uploaded file first and only then stores it.
+// Both passes stream the rows so memory use doesn't grow with the file size.
+// Every upload is recorded in the upload history, a file matching the last
+// successful upload is rejected unless ctr.Force is set.
+func (sc *ServerCatalog) UploadCatalog(ctx context.Context, ctr *dto.UploadCatalogCtr) (*dto.UploadCatalogResp, error) {
+	mode, err := uploadMode(ctr.Mode)
 	if err != nil {
-		return fmt.Errorf("usecase:server_catalog:failed to read file")
+		return nil, err
 	}
-	f, err := excelize.OpenReader(bytes.NewReader(data))
+
+	cf, err := openCatalog(ctr, sc.workbookOptions())
 	if err != nil {
-		return fmt.Errorf("usecase:server_catalog:invalid XLSX file")
+		return nil, err
 	}
-	defer f.Close()
+	defer cf.Close()
 
-	sheetName := f.GetSheetName(0)
-	if sheetName == "" {
-		return fmt.Errorf("usecase:server_catalog:no sheet found")
+	checksum, err := cf.checksum()
+	if err != nil {
+		return nil, err
+	}
+	upload, err := sc.startUpload(ctx, &models.Upload{
+		Source:   models.UploadSourceFile,
+		Filename: ctr.Filename,
+		Size:     cf.size,
+		Checksum: checksum,
+		Uploader: ctr.Uploader,
+		Mode:     mode,
+	}, ctr.Force)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := sc.importCatalog(ctx, ctr, cf, mode)
+	sc.finishUpload(ctx, upload, resp, err)
+	if err != nil {
+		return nil, err
 	}
+	return resp, nil
+}
 
-	rows, err := f.GetRows(sheetName)
-	if err != nil || len(rows) < 2 {
-		return fmt.Errorf("usecase:server_catalog:no data in the sheet")
+// importCatalog validates and stores an opened catalog file, see UploadCatalog
+func (sc *ServerCatalog) importCatalog(ctx context.Context, ctr *dto.UploadCatalogCtr, cf *catalogFile, mode string) (*dto.UploadCatalogResp, error) {
+	reportProgress(ctr, models.JobStateParsing, 0)
+	_, sheets, err := sc.validateCatalog(cf, nil, func(rows int) {
+		reportProgress(ctr, models.JobStateParsing, rows)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if len(rows)-1 > 1000 {
-		return fmt.Errorf("usecase:server_catalog:maximum number of rows exceeded (1000)")
+	reportProgress(ctr, models.JobStateInserting, 0)
+	resp, err := sc.storeCatalog(ctx, mode, func(add func(catalog models.ServerCatalog) error) error {
+		return sc.walkCatalog(cf, func(row catalogRow) error {
+			catalog, _ := sc.parseRow(row)
+			return add(catalog)
+		}, nil, nil)
+	}, func(rows int) {
+		reportProgress(ctr, models.JobStateInserting, rows)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Validate header
-	expected := []string{"Model", "RAM", "HDD", "Location", "Price"}
-	header := rows[0]
-	for i, col := range expected {
-		if i >= len(header) || strings.TrimSpace(header[i]) != col {
-			return fmt.Errorf("usecase:server_catalog:invalid XLSX columns")
-		}
+	resp.Sheets = sheets
+	return resp, nil
+}
+
+// reportProgress forwards the progress of an upload to the caller when it asked for it
+func reportProgress(ctr *dto.UploadCatalogCtr, stage string, rows int) {
+	if ctr.Progress != nil {
+		ctr.Progress(stage, rows)
+	}
+}
+
+// PreviewCatalog runs the full parse and validation pipeline of UploadCatalog
+// and returns the rows as they would be stored, without writing them.
+func (sc *ServerCatalog) PreviewCatalog(ctx context.Context, ctr *dto.UploadCatalogCtr) (*dto.CatalogPreviewResp, error) {
+	cf, err := openCatalog(ctr, sc.workbookOptions())
+	if err != nil {
+		return nil, err
+	}
+	defer cf.Close()
+
+	resp := &dto.CatalogPreviewResp{
+		Rows: make([]dto.CatalogPreviewRow, 0),
+	}
+	resp.Warnings, resp.Sheets, err = sc.validateCatalog(cf, func(row catalogRow, catalog models.ServerCatalog) {
+		resp.Rows = append(resp.Rows, transformer.TransformPreviewRow(row.Sheet, row.No, catalog))
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// uploadMode validates the requested upload mode, append is the default
+func uploadMode(mode string) (string, error) {
+	switch mode {
+	case "":
+		return dto.UploadModeAppend, nil
+	case dto.UploadModeAppend, dto.UploadModeReplace, dto.UploadModeUpsert:
+		return mode, nil
 	}
+	return "", fmt.Errorf("usecase:server_catalog:unknown upload mode %q", mode)
+}
 
-	var inserted int
-	catalogs := make([]models.ServerCatalog, 0)
-	for idx, row := range rows[1:] {
-		if len(row) < 5 {
-			return fmt.Errorf("usecase:server_catalog:rows too short")
+// storeCatalog writes the servers produced by walk into a new catalog version in
+// batches and activates it. Append and upsert start from the servers of the active
+// version, replace starts from an empty one. Versions share their servers, so an
+// append or upsert only writes the servers it inserts or reprices next to one
+// membership row per server of the version. The whole write runs in a single
+// transaction so a failure never leaves a partial version behind. The number of
+// servers stored so far is reported to progress when it is set.
+func (sc *ServerCatalog) storeCatalog(ctx context.Context, mode string, walk func(add func(catalog models.ServerCatalog) error) error, progress func(rows int)) (*dto.UploadCatalogResp, error) {
+	resp := &dto.UploadCatalogResp{Mode: mode}
+
+	err := sc.SCRepo.Transaction(ctx, func(repo repository.CatalogRepository) error {
+		active, err := repo.GetActiveVersion(ctx)
+		if err != nil && !errors.Is(err, utils.ErrVersionNotFound) {
+			return uploadFailed(err)
 		}
 
-		model := strings.TrimSpace(row[0])
-		if model == "" {
-			return fmt.Errorf("usecase:server_catalog:model is required at %d", idx+2)
+		version := &models.CatalogVersion{Mode: mode}
+		if err := repo.CreateVersion(ctx, version); err != nil {
+			return uploadFailed(err)
+		}
+		if active != nil && mode == dto.UploadModeReplace {
+			resp.Deleted = active.ServerCount
+		} else if active != nil {
+			copied, err := repo.CopyVersion(ctx, active.ID, version.ID)
+			if err != nil {
+				return uploadFailed(err)
+			}
+			version.ServerCount = int(copied)
 		}
 
-		ramSize, ramType, err := parseRAM(row[1])
-		if err != nil {
-			return fmt.Errorf("usecase:server_catalog:Invalid RAM at row %d: %s\n", idx+2, row[1])
+		var stored int
+		splitter, modelIDs := sc.modelSplitter(), map[string]uint{}
+		locations, locationIDs := sc.locationSplitter(), map[string]uint{}
+		batch := make([]models.ServerCatalog, 0, sc.batchSize())
+		flush := func() error {
+			if len(batch) < 1 {
+				return nil
+			}
+			if err := ctx.Err(); err != nil {
+				return uploadFailed(err)
+			}
+			if err := splitter.save(ctx, repo, modelIDs, batch); err != nil {
+				return uploadFailed(err)
+			}
+			if err := locations.save(ctx, repo, locationIDs, batch); err != nil {
+				return uploadFailed(err)
+			}
+
+			if mode == dto.UploadModeUpsert {
+				inserted, updated, unchanged, err := repo.Upsert(ctx, batch)
+				if err != nil {
+					return uploadFailed(err)
+				}
+				resp.Inserted += inserted
+				resp.Updated += updated
+				resp.Unchanged += unchanged
+			} else {
+				if err := repo.Upload(ctx, batch); err != nil {
+					return uploadFailed(err)
+				}
+				resp.Inserted += len(batch)
+			}
+
+			stored += len(batch)
+			batch = batch[:0]
+			if progress != nil {
+				progress(stored)
+			}
+			return nil
 		}
 
-		hddCount, hddSize, hddType, err := parseHDD(row[2])
+		err = walk(func(catalog models.ServerCatalog) error {
+			catalog.VersionID = version.ID
+			batch = append(batch, catalog)
+			if len(batch) < sc.batchSize() {
+				return nil
+			}
+			return flush()
+		})
 		if err != nil {
-			return fmt.Errorf("usecase:server_catalog:Invalid HDD at row %d: %s", idx+2, row[2])
+			return err
 		}
-
-		location := strings.TrimSpace(row[3])
-		if location == "" {
-			return fmt.Errorf("usecase:server_catalog:Location is required at row %d", idx+2)
+		if err := flush(); err != nil {
+			return err
 		}
 
-		price, currencySymbol, err := parsePrice(row[4])
-		if err != nil {
-			return fmt.Errorf("usecase:server_catalog:Invalid Price at row %d: %s", idx+2, row[4])
+		version.ServerCount += resp.Inserted
+		if err := repo.UpdateVersion(ctx, version); err != nil {
+			return uploadFailed(err)
+		}
+		if _, err := repo.ActivateVersion(ctx, version.ID); err != nil {
+			return uploadFailed(err)
 		}
+		resp.Version = version.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		ramTypeID, err := utils.GetRAMTypeID(ramType)
-		if err != nil {
+	return resp, nil
+}
+
+// uploadFailed is returned when the database rejects an upload. Both utils.ErrUploadFailed
+// and the cause can be matched with errors.Is.
+func uploadFailed(err error) error {
+	return fmt.Errorf("usecase:server_catalog:: failed to upload %w: %w", utils.ErrUploadFailed, err)
+}
+
+// maxRowsExceeded reports whether count servers are more than the upload policy allows
+func (sc *ServerCatalog) maxRowsExceeded(count int) error {
+	if sc.Policy == nil || sc.Policy.MaxRows < 1 || count <= sc.Policy.MaxRows {
+		return nil
+	}
+	return fmt.Errorf("usecase:server_catalog:maximum number of rows exceeded (%d)", sc.Policy.MaxRows)
+}
+
+// batchSize returns the number of servers stored per repository call
+func (sc *ServerCatalog) batchSize() int {
+	if sc.Policy == nil || sc.Policy.BatchSize < 1 {
+		return defaultBatchSize
+	}
+	return sc.Policy.BatchSize
+}
+
+// workbookOptions returns the unzip limits of the upload policy, excelize
+// falls back to its own defaults for the ones left at 0
+func (sc *ServerCatalog) workbookOptions() excelize.Options {
+	if sc.Policy == nil {
+		return excelize.Options{}
+	}
+	return excelize.Options{
+		UnzipSizeLimit:    sc.Policy.UnzipSizeLimit,
+		UnzipXMLSizeLimit: sc.Policy.UnzipXMLSizeLimit,
+	}
+}
+
+// validateCatalog is the first pass over an upload. Every row is parsed and
+// checked against the upload policy without touching the database, valid rows
+// are handed to fn when it is set. Every batch of rows read is reported to
+// progress when it is set. It returns the warnings found on the way and, when
+// sheets were selected, the result of every sheet.
+func (sc *ServerCatalog) validateCatalog(cf *catalogFile, fn func(row catalogRow, catalog models.ServerCatalog), progress func(rows int)) ([]string, []dto.SheetResult, error) {
+	var warnings []string
+	var count int
+	report := &utils.ValidationError{}
+	dups := newDuplicateCheck()
+
+	sheets := make(map[string]*dto.SheetResult, len(cf.sheets))
+	for _, sheet := range cf.sheets {
+		sheets[sheet] = &dto.SheetResult{Name: sheet}
+	}
+
+	err := sc.walkCatalog(cf, func(row catalogRow) error {
+		count++
+		if err := sc.maxRowsExceeded(count); err != nil {
 			return err
 		}
+		if progress != nil && count%sc.batchSize() == 0 {
+			progress(count)
+		}
 
-		hddTypeID, err := utils.GetHDDTypeID(hddType)
-		if err != nil {
-			return err
+		catalog, rowErrs := sc.parseRow(row)
+		if len(rowErrs) > 0 {
+			report.Rows = append(report.Rows, rowErrs...)
+			return nil
+		}
+		if result, ok := sheets[row.Sheet]; ok {
+			result.Servers++
 		}
 
-		currencyID, err := utils.GetCurrencyID(currencySymbol)
-		if err != nil {
-			return err
+		if dups.add(row, catalog.NaturalKey(), len(warnings)) {
+			// filled in by resolveDuplicates
+			warnings = append(warnings, "")
 		}
 
-		catalog := models.ServerCatalog{
-			Model:    model,
-			RamSize:  ramSize,
-			RamType:  ramTypeID,
-			HDDSize:  hddSize,
-			HDDCount: hddCount,
-			HDDType:  hddTypeID,
-			Location: location,
-			Price:    price,
-			Currency: currencyID,
+		if fn != nil {
+			fn(row, catalog)
 		}
+		return nil
+	}, func(row catalogRow) {
+		warnings = append(warnings, fmt.Sprintf("%s is empty and was skipped", row.label()))
+	}, func(sheet string, reason string) {
+		sheets[sheet].Skipped = true
+		sheets[sheet].Reason = reason
+		warnings = append(warnings, fmt.Sprintf("sheet %s was skipped: %s", sheet, reason))
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if progress != nil {
+		progress(count)
+	}
 
-		catalogs = append(catalogs, catalog)
-		inserted++
+	var results []dto.SheetResult
+	if cf.named {
+		results = make([]dto.SheetResult, 0, len(cf.sheets))
+		for _, sheet := range cf.sheets {
+			results = append(results, *sheets[sheet])
+		}
 	}
 
-	if err := sc.SCRepo.Upload(ctx, catalogs); err != nil {
-		return fmt.Errorf("usecase:server_catalog:: failed to upload %v", utils.ErrUploadFailed)
+	if count < 1 {
+		if cf.matchAll {
+			return nil, nil, fmt.Errorf("usecase:server_catalog:no sheet with a catalog header")
+		}
+		return nil, nil, fmt.Errorf("usecase:server_catalog:no data in the sheet")
 	}
-	return nil
+	if len(report.Rows) > 0 {
+		return nil, nil, report
+	}
+
+	if warnings, err = sc.resolveDuplicates(cf, dups, warnings); err != nil {
+		return nil, nil, err
+	}
+	return warnings, results, nil
 }
 
-func parseRAM(ram string) (int, string, error) {
-	ram = strings.ToUpper(strings.TrimSpace(ram))
-	re := regexp.MustCompile(`^(\d+)\s*GB\s*(\w+)$`)
-	matches := re.FindStringSubmatch(ram)
-	if len(matches) != 3 {
-		return 0, "", fmt.Errorf("invalid RAM format")
+// resolveDuplicates reads the natural keys of the first rows the duplicate
+// candidates are compared against. It only walks the catalog again when a
+// row hash matched an earlier one.
+func (sc *ServerCatalog) resolveDuplicates(cf *catalogFile, dups *duplicateCheck, warnings []string) ([]string, error) {
+	if len(dups.candidates) == 0 {
+		return warnings, nil
 	}
-	size, err := strconv.Atoi(matches[1])
+
+	firstKeys := dups.firstRows()
+	err := sc.walkCatalog(cf, func(row catalogRow) error {
+		ref := rowRef{Sheet: row.Sheet, No: row.No}
+		if _, ok := firstKeys[ref]; ok {
+			catalog, _ := sc.parseRow(row)
+			firstKeys[ref] = catalog.NaturalKey()
+		}
+		return nil
+	}, nil, nil)
 	if err != nil {
-		return 0, "", err
+		return nil, err
 	}
-	typ := matches[2]
-	return size, typ, err
+	return dups.resolve(warnings, firstKeys), nil
 }
 
-func parseHDD(hdd string) (int, int, string, error) {
-	hdd = strings.TrimSpace(hdd)
-	re := regexp.MustCompile(`(?i)^(\d+)x(\d+)(TB|GB)([A-Z0-9]+)$`)
-	matches := re.FindStringSubmatch(hdd)
-	if len(matches) != 5 {
-		return 0, 0, "", fmt.Errorf("invalid HDD format: %q", hdd)
+// catalogRow is a non blank data row of an uploaded catalog
+type catalogRow struct {
+	// Sheet is only set when the sheets of the workbook were selected explicitly
+	Sheet string
+	No    int
+	Cells []string
+	// Raw holds the unformatted cells of XLSX rows, numeric cells differ from their formatted value
+	Raw    []string
+	Layout columnLayout
+}
+
+// label names the row in warnings
+func (row catalogRow) label() string {
+	if row.Sheet != "" {
+		return fmt.Sprintf("row %d of sheet %s", row.No, row.Sheet)
+	}
+	return fmt.Sprintf("row %d", row.No)
+}
+
+// headerError is returned when a sheet doesn't start with a valid catalog header
+type headerError struct {
+	sheet  string
+	reason string
+}
+
+func (he *headerError) Error() string {
+	if he.sheet != "" {
+		return fmt.Sprintf("usecase:server_catalog:%s in sheet %q", he.reason, he.sheet)
+	}
+	return "usecase:server_catalog:" + he.reason
+}
+
+// walkCatalog walks the selected sheets of the catalog. The columns of every sheet
+// are resolved from its header and fn is called for every non blank data row.
+// Blank rows are reported to onBlank when it is set. When every sheet with a
+// catalog header is imported, sheets without one are skipped and reported to
+// onSkip when it is set.
+func (sc *ServerCatalog) walkCatalog(cf *catalogFile, fn func(row catalogRow) error, onBlank func(row catalogRow), onSkip func(sheet string, reason string)) error {
+	for _, sheet := range cf.sheets {
+		err := sc.walkSheet(cf, sheet, fn, onBlank)
+		var herr *headerError
+		if cf.matchAll && errors.As(err, &herr) {
+			if onSkip != nil {
+				onSkip(sheet, herr.reason)
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkSheet walks the rows of a single sheet, see walkCatalog
+func (sc *ServerCatalog) walkSheet(cf *catalogFile, sheet string, fn func(row catalogRow) error, onBlank func(row catalogRow)) error {
+	label := ""
+	if cf.named {
+		label = sheet
 	}
 
-	count, err := strconv.Atoi(matches[1])
+	it, err := cf.rows(sheet)
 	if err != nil {
-		return 0, 0, "", err
+		return &headerError{sheet: label, reason: "no data in the sheet"}
+	}
+	defer it.Close()
+
+	if !it.Next() {
+		if err := it.Err(); err != nil {
+			return err
+		}
+		return &headerError{sheet: label, reason: "no data in the sheet"}
 	}
 
-	size, err := strconv.Atoi(matches[2])
+	header, err := it.Row()
 	if err != nil {
-		return 0, 0, "", err
+		return &headerError{sheet: label, reason: "no data in the sheet"}
+	}
+	layout, err := resolveColumns(header, sc.columnAliases())
+	if err != nil {
+		return &headerError{sheet: label, reason: fmt.Sprintf("invalid %s columns: %v", strings.ToUpper(cf.format), err)}
+	}
+
+	for rowNo := 2; it.Next(); rowNo++ {
+		cells, err := it.Row()
+		if err != nil {
+			return fmt.Errorf("usecase:server_catalog:failed to read row %d: %v", rowNo, err)
+		}
+		raw, err := it.Raw()
+		if err != nil {
+			return fmt.Errorf("usecase:server_catalog:failed to read row %d: %v", rowNo, err)
+		}
+		row := catalogRow{Sheet: label, No: rowNo, Cells: cells, Raw: raw, Layout: layout}
+		if isBlankRow(cells) {
+			if onBlank != nil {
+				onBlank(row)
+			}
+			continue
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
 	}
 
-	unit := strings.ToUpper(matches[3])
-	typ := strings.ToUpper(matches[4])
+	return it.Err()
+}
+
+// defaultBatchSize is used when the upload policy doesn't define a batch size
+const defaultBatchSize = 500
 
-	// Convert to GB
-	sizeGB := utils.ConvertToGB(size, unit)
+// catalogColumns are the canonical names of the catalog columns
+var catalogColumns = []string{"Model", "RAM", "HDD", "Location", "Price"}
 
-	return count, sizeGB, typ, nil
+// catalog columns, indexes of catalogColumns and columnLayout
+const (
+	colModel = iota
+	colRAM
+	colHDD
+	colLocation
+	colPrice
+	colCount
+)
+
+// isBlankRow reports whether every cell of the row is empty
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
 }
 
-func parsePrice(price string) (float64, string, error) {
-	price = strings.TrimSpace(price)
+// parseRow validates a single sheet row and converts it into a catalog entry.
+// Every invalid cell of the row is reported instead of stopping at the first one.
+func (sc *ServerCatalog) parseRow(row catalogRow) (models.ServerCatalog, []utils.RowError) {
+	var catalog models.ServerCatalog
+	var errs []utils.RowError
+
+	value := func(col int) string {
+		if row.Layout[col] < len(row.Cells) {
+			return row.Cells[row.Layout[col]]
+		}
+		return ""
+	}
+	rawValue := func(col int) string {
+		if row.Layout[col] < len(row.Raw) {
+			return strings.TrimSpace(row.Raw[row.Layout[col]])
+		}
+		return ""
+	}
+	fail := func(col int, reason string) {
+		cell, _ := excelize.CoordinatesToCellName(row.Layout[col]+1, row.No)
+		errs = append(errs, utils.RowError{
+			Sheet:  row.Sheet,
+			Row:    row.No,
+			Column: catalogColumns[col],
+			Cell:   cell,
+			Value:  value(col),
+			Reason: reason,
+		})
+	}
+
+	catalog.Model = strings.TrimSpace(value(colModel))
+	if catalog.Model == "" {
+		fail(colModel, "model is required")
+	}
+
+	if ram, err := parseRAM(value(colRAM)); err != nil {
+		fail(colRAM, err.Error())
+	} else if ramTypeID, err := sc.Lookups.RAMTypeID(ram.Type); err != nil {
+		fail(colRAM, err.Error())
+	} else {
+		catalog.RamSize = ram.Size
+		catalog.RamType = ramTypeID
+		catalog.RamECC = ram.ECC
+		catalog.RamSpeed = ram.Speed
+	}
 
-	re := regexp.MustCompile(`^([^\d]+)\s*([\d\.]+)$`)
-	matches := re.FindStringSubmatch(price)
-	if len(matches) != 3 {
-		return 0, "", fmt.Errorf("invalid price format: %s", price)
+	if groups, err := parseHDD(value(colHDD)); err != nil {
+		fail(colHDD, err.Error())
+	} else {
+		for _, group := range groups {
+			hddTypeID, err := sc.Lookups.HDDTypeID(group.Type)
+			if err != nil {
+				fail(colHDD, err.Error())
+				break
+			}
+			catalog.Disks = append(catalog.Disks, models.ServerDisk{
+				HDDCount: group.Count,
+				HDDSize:  group.Size,
+				HDDType:  hddTypeID,
+			})
+		}
 	}
 
-	currency := strings.TrimSpace(matches[1])
+	catalog.Location = strings.TrimSpace(value(colLocation))
+	if catalog.Location == "" {
+		fail(colLocation, "location is required")
+	}
 
-	if currency == "S$" {
-		currency = utils.CurrencySymbolSGD
+	if price, currency, err := parsePrice(value(colPrice), rawValue(colPrice)); err != nil {
+		fail(colPrice, err.Error())
+	} else if currencyID, err := sc.priceCurrencyID(currency); err != nil {
+		fail(colPrice, err.Error())
+	} else {
+		catalog.Price = price
+		catalog.Currency = currencyID
 	}
 
-	amount, err := strconv.ParseFloat(matches[2], 64)
+	return catalog, errs
+}
+
+// ramSpec is a parsed RAM value, its size is in GB and its speed in MT/s (0 when not given)
+type ramSpec struct {
+	Size  int
+	Type  string
+	Speed int
+	ECC   bool
+}
+
+// ramPattern matches a RAM value: size, unit, type, optional speed and ECC markers
+var ramPattern = regexp.MustCompile(`^(\d+)\s*(GB|TB)\s*([A-Z]+\d*)(?:\s*-?\s*(\d+)\s*(?:MT/S|MHZ)?)?((?:\s*(?:ECC|REG|RDIMM))*)$`)
+
+// parseRAM parses RAM values such as 16GBDDR3, "64GB DDR4 ECC", 128GBDDR5-4800 and "1TB DDR4".
+// Registered memory (REG, RDIMM) is always ECC memory.
+func parseRAM(ram string) (ramSpec, error) {
+	ram = strings.ToUpper(strings.TrimSpace(ram))
+	matches := ramPattern.FindStringSubmatch(ram)
+	if len(matches) != 6 {
+		return ramSpec{}, fmt.Errorf("invalid RAM format")
+	}
+	size, err := strconv.Atoi(matches[1])
 	if err != nil {
-		return 0, "", fmt.Errorf("invalid amount format: %s", matches[2])
+		return ramSpec{}, err
+	}
+
+	spec := ramSpec{
+		Size: utils.ConvertToGB(size, matches[2]),
+		Type: matches[3],
+		ECC:  strings.TrimSpace(matches[5]) != "",
+	}
+	if matches[4] != "" {
+		if spec.Speed, err = strconv.Atoi(matches[4]); err != nil {
+			return ramSpec{}, err
+		}
+	}
+	return spec, nil
+}
+
+// diskGroup is a single NxSIZEUNITTYPE group of an HDD value, its size is in GB
+type diskGroup struct {
+	Count int
+	Size  int
+	Type  string
+}
+
+// diskGroupPattern matches a single disk group: count, size, unit and type
+var diskGroupPattern = regexp.MustCompile(`(?i)^(\d+)x(\d+)(TB|GB)([A-Z0-9]+)$`)
+
+// parseHDD parses an HDD value made of one or more disk groups separated by "+",
+// e.g. 2x120GBSSD+4x2TBSATA2
+func parseHDD(hdd string) ([]diskGroup, error) {
+	hdd = strings.TrimSpace(hdd)
+
+	groups := make([]diskGroup, 0, 1)
+	for _, group := range strings.Split(hdd, "+") {
+		matches := diskGroupPattern.FindStringSubmatch(strings.TrimSpace(group))
+		if len(matches) != 5 {
+			return nil, fmt.Errorf("invalid HDD format: %q", hdd)
+		}
+
+		count, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := strconv.Atoi(matches[2])
+		if err != nil {
+			return nil, err
+		}
+
+		unit := strings.ToUpper(matches[3])
+		typ := strings.ToUpper(matches[4])
+
+		// Convert to GB
+		groups = append(groups, diskGroup{Count: count, Size: utils.ConvertToGB(size, unit), Type: typ})
 	}
 
-	return amount, currency, nil
+	return groups, nil
 }
 
-func (sc *ServerCatalog) GetLocations(ctx context.Context) ([]string, error) {
+func (sc *ServerCatalog) GetLocations(ctx context.Context) ([]dto.LocationResp, error) {
 	locs, err := sc.SCRepo.GetLocations(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return locs, nil
+	return transformer.TransformLocations(locs), nil
 }
 
 func (sc *ServerCatalog) GetHDDTypes(ctx context.Context) ([]string, error) {
@@ -220,7 +695,32 @@ func (sc *ServerCatalog) GetListOfServers(ctx context.Context, ctr *dto.ListServ
 		return nil, utils.ErrServerNotFound
 	}
 
-	transformedList := transformer.TransformServerList(result)
+	transformedList := transformer.TransformServerList(result, sc.Lookups)
 
 	return transformedList, nil
 }
+
+// GetVersions returns every catalog version, newest first
+func (sc *ServerCatalog) GetVersions(ctx context.Context) ([]dto.CatalogVersionResp, error) {
+	versions, err := sc.SCRepo.GetVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.CatalogVersionResp, 0, len(versions))
+	for i := range versions {
+		resp = append(resp, transformer.TransformCatalogVersion(&versions[i]))
+	}
+	return resp, nil
+}
+
+// ActivateVersion makes an older version the active catalog again, which rolls back later uploads
+func (sc *ServerCatalog) ActivateVersion(ctx context.Context, id uint) (*dto.CatalogVersionResp, error) {
+	version, err := sc.SCRepo.ActivateVersion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := transformer.TransformCatalogVersion(version)
+	return &resp, nil
+}
@@ -4,15 +4,23 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"github.com/server-catalog/internal/config"
 	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/lookup"
 	"github.com/server-catalog/internal/utils"
 	"github.com/server-catalog/models"
-	_ "github.com/server-catalog/repository"
+	"github.com/server-catalog/repository"
 	"github.com/xuri/excelize/v2"
 	_ "mime/multipart"
+	"reflect"
 	"testing"
 )
 
+var testPolicy = &config.UploadPolicy{MaxRows: 1000, BatchSize: 500}
+
+// testLookups serves the spec types and currencies seeded by the migrations
+var testLookups = lookup.Static(lookup.Seed)
+
 type mockFile struct {
 	*bytes.Reader
 }
@@ -23,16 +31,122 @@ func (m *mockFile) Close() error {
 
 type mockCatalogRepository struct {
 	uploadFunc       func(ctx context.Context, catalogs []models.ServerCatalog) error
-	getLocationsFunc func(ctx context.Context) ([]string, error)
+	upsertFunc       func(ctx context.Context, catalogs []models.ServerCatalog) (int, int, int, error)
+	// activeVersion is the version served before an upload, nil while the catalog is empty
+	activeVersion  *models.CatalogVersion
+	versions       []models.CatalogVersion
+	versionServers map[uint][]models.ServerCatalog
+	// serverModels are the models saved by uploads in the order they were first seen
+	serverModels []models.ServerModel
+	// locations are the locations saved by uploads in the order they were first seen
+	locations []models.Location
+	// uploads is the upload history, oldest first
+	uploads          []models.Upload
+	getLocationsFunc func(ctx context.Context) ([]models.Location, error)
 	getHDDTypesFunc  func(ctx context.Context) ([]string, error)
 	getServersFunc   func(ctx context.Context, ctr *dto.ListServersCtr) ([]models.ServerCatalog, error)
 }
 
+func (m *mockCatalogRepository) Transaction(ctx context.Context, fn func(repo repository.CatalogRepository) error) error {
+	return fn(m)
+}
+
+func (m *mockCatalogRepository) Upsert(ctx context.Context, catalogs []models.ServerCatalog) (int, int, int, error) {
+	return m.upsertFunc(ctx, catalogs)
+}
+
+func (m *mockCatalogRepository) SaveModels(ctx context.Context, serverModels []*models.ServerModel) error {
+	for _, serverModel := range serverModels {
+		serverModel.ID = uint(len(m.serverModels) + 1)
+		m.serverModels = append(m.serverModels, *serverModel)
+	}
+	return nil
+}
+
+func (m *mockCatalogRepository) SaveLocations(ctx context.Context, locations []*models.Location) error {
+	for _, location := range locations {
+		location.ID = uint(len(m.locations) + 1)
+		m.locations = append(m.locations, *location)
+	}
+	return nil
+}
+
+func (m *mockCatalogRepository) CreateVersion(ctx context.Context, version *models.CatalogVersion) error {
+	version.ID = uint(len(m.versions) + 1)
+	m.versions = append(m.versions, *version)
+	return nil
+}
+
+func (m *mockCatalogRepository) UpdateVersion(ctx context.Context, version *models.CatalogVersion) error {
+	m.versions[version.ID-1] = *version
+	return nil
+}
+
+func (m *mockCatalogRepository) CopyVersion(ctx context.Context, from, to uint) (int64, error) {
+	return int64(m.activeVersion.ServerCount), nil
+}
+
+func (m *mockCatalogRepository) GetVersions(ctx context.Context) ([]models.CatalogVersion, error) {
+	return m.versions, nil
+}
+
+func (m *mockCatalogRepository) GetVersion(ctx context.Context, id uint) (*models.CatalogVersion, error) {
+	if id < 1 || int(id) > len(m.versions) {
+		return nil, utils.ErrVersionNotFound
+	}
+	return &m.versions[id-1], nil
+}
+
+func (m *mockCatalogRepository) GetVersionServers(ctx context.Context, id uint) ([]models.ServerCatalog, error) {
+	return m.versionServers[id], nil
+}
+
+func (m *mockCatalogRepository) GetActiveVersion(ctx context.Context) (*models.CatalogVersion, error) {
+	if m.activeVersion == nil {
+		return nil, utils.ErrVersionNotFound
+	}
+	return m.activeVersion, nil
+}
+
+func (m *mockCatalogRepository) ActivateVersion(ctx context.Context, id uint) (*models.CatalogVersion, error) {
+	if id < 1 || int(id) > len(m.versions) {
+		return nil, utils.ErrVersionNotFound
+	}
+	m.versions[id-1].Active = true
+	m.activeVersion = &m.versions[id-1]
+	return m.activeVersion, nil
+}
+
+func (m *mockCatalogRepository) CreateUpload(ctx context.Context, upload *models.Upload) error {
+	upload.ID = uint(len(m.uploads) + 1)
+	m.uploads = append(m.uploads, *upload)
+	return nil
+}
+
+func (m *mockCatalogRepository) UpdateUpload(ctx context.Context, upload *models.Upload) error {
+	m.uploads[upload.ID-1] = *upload
+	return nil
+}
+
+func (m *mockCatalogRepository) GetUploads(ctx context.Context, page *utils.Page) ([]models.Upload, error) {
+	page.Total = len(m.uploads)
+	return m.uploads, nil
+}
+
+func (m *mockCatalogRepository) GetLastSuccessfulUpload(ctx context.Context) (*models.Upload, error) {
+	for i := len(m.uploads) - 1; i >= 0; i-- {
+		if m.uploads[i].Outcome == models.UploadOutcomeSucceeded {
+			return &m.uploads[i], nil
+		}
+	}
+	return nil, utils.ErrUploadNotFound
+}
+
 func (m *mockCatalogRepository) Upload(ctx context.Context, catalogs []models.ServerCatalog) error {
 	return m.uploadFunc(ctx, catalogs)
 }
 
-func (m *mockCatalogRepository) GetLocations(ctx context.Context) ([]string, error) {
+func (m *mockCatalogRepository) GetLocations(ctx context.Context) ([]models.Location, error) {
 	return m.getLocationsFunc(ctx)
 }
 
@@ -44,6 +158,21 @@ func (m *mockCatalogRepository) GetServers(ctx context.Context, ctr *dto.ListSer
 	return m.getServersFunc(ctx, ctr)
 }
 
+func (m *mockCatalogRepository) StreamServers(ctx context.Context, ctr *dto.ListServersCtr, batchSize int, fn func(servers []models.ServerCatalog) error) error {
+	servers, err := m.getServersFunc(ctx, ctr)
+	if err != nil {
+		return err
+	}
+	for len(servers) > 0 {
+		n := min(batchSize, len(servers))
+		if err := fn(servers[:n]); err != nil {
+			return err
+		}
+		servers = servers[n:]
+	}
+	return nil
+}
+
 func createTestExcelFile(data [][]string) (*bytes.Buffer, error) {
 	f := excelize.NewFile()
 	sheet := "Sheet1"
@@ -88,7 +217,8 @@ func TestServerCatalog_UploadCatalog(t *testing.T) {
 			mockUpload: func(ctx context.Context, catalogs []models.ServerCatalog) error {
 				return nil
 			},
-			expectedError: errors.New("usecase:server_catalog:invalid XLSX columns"),
+			expectedError: errors.New("usecase:server_catalog:invalid XLSX columns: missing Model (accepted: Model); " +
+				"missing RAM (accepted: RAM); missing HDD (accepted: HDD)"),
 		},
 		{
 			name: "repository error",
@@ -99,7 +229,7 @@ func TestServerCatalog_UploadCatalog(t *testing.T) {
 			mockUpload: func(ctx context.Context, catalogs []models.ServerCatalog) error {
 				return errors.New("database error")
 			},
-			expectedError: errors.New("usecase:server_catalog:: failed to upload failed to upload data into the database"),
+			expectedError: errors.New("usecase:server_catalog:: failed to upload failed to upload data into the database: database error"),
 		},
 		{
 			name: "too many rows",
@@ -132,14 +262,14 @@ func TestServerCatalog_UploadCatalog(t *testing.T) {
 				uploadFunc: tt.mockUpload,
 			}
 
-			uc := New(mockRepo)
+			uc := New(mockRepo, testPolicy, testLookups)
 
 			ctr := &dto.UploadCatalogCtr{
 				File: &mockFile{bytes.NewReader(excelBuffer.Bytes())},
 			}
 
 			// Test upload
-			err = uc.UploadCatalog(context.Background(), ctr)
+			_, err = uc.UploadCatalog(context.Background(), ctr)
 			if (err != nil && tt.expectedError == nil) ||
 				(err == nil && tt.expectedError != nil) ||
 				(err != nil && tt.expectedError != nil && err.Error() != tt.expectedError.Error()) {
@@ -149,24 +279,314 @@ func TestServerCatalog_UploadCatalog(t *testing.T) {
 	}
 }
 
+func TestServerCatalog_UploadCatalog_ErrorCause(t *testing.T) {
+	excelBuffer, err := createTestExcelFile([][]string{
+		{"Model", "RAM", "HDD", "Location", "Price"},
+		{"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "$35.99"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	cause := errors.New("Error 1390: Prepared statement contains too many placeholders")
+	mockRepo := &mockCatalogRepository{
+		uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+			return cause
+		},
+	}
+
+	_, err = New(mockRepo, testPolicy, testLookups).UploadCatalog(context.Background(), &dto.UploadCatalogCtr{
+		File: &mockFile{bytes.NewReader(excelBuffer.Bytes())},
+	})
+	if !errors.Is(err, utils.ErrUploadFailed) {
+		t.Errorf("UploadCatalog() error = %v, want %v", err, utils.ErrUploadFailed)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("UploadCatalog() error = %v, want it to keep %v", err, cause)
+	}
+}
+
+func TestServerCatalog_UploadCatalog_Canceled(t *testing.T) {
+	data := make([][]string, 5)
+	data[0] = []string{"Model", "RAM", "HDD", "Location", "Price"}
+	for i := 1; i < len(data); i++ {
+		data[i] = []string{"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "$35.99"}
+	}
+	excelBuffer, err := createTestExcelFile(data)
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	// the upload is canceled once the first batch is stored
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var batches int
+	mockRepo := &mockCatalogRepository{
+		uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+			batches++
+			cancel()
+			return nil
+		},
+	}
+
+	_, err = New(mockRepo, &config.UploadPolicy{BatchSize: 2}, testLookups).UploadCatalog(ctx, &dto.UploadCatalogCtr{
+		File: &mockFile{bytes.NewReader(excelBuffer.Bytes())},
+	})
+	if !errors.Is(err, utils.ErrUploadFailed) {
+		t.Errorf("UploadCatalog() error = %v, want %v", err, utils.ErrUploadFailed)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("UploadCatalog() error = %v, want it to keep %v", err, context.Canceled)
+	}
+	if batches != 1 {
+		t.Errorf("UploadCatalog() stored %d batches, want 1", batches)
+	}
+}
+
+func TestServerCatalog_UploadCatalog_Batches(t *testing.T) {
+	data := make([][]string, 1202)
+	data[0] = []string{"Model", "RAM", "HDD", "Location", "Price"}
+	for i := 1; i < len(data); i++ {
+		data[i] = []string{"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "$35.99"}
+	}
+	data[600] = nil
+
+	excelBuffer, err := createTestExcelFile(data)
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	var batches []int
+	mockRepo := &mockCatalogRepository{
+		uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+			batches = append(batches, len(catalogs))
+			return nil
+		},
+	}
+
+	// no row limit, 500 servers per batch
+	uc := New(mockRepo, &config.UploadPolicy{BatchSize: 500}, testLookups)
+	_, err = uc.UploadCatalog(context.Background(), &dto.UploadCatalogCtr{
+		File: &mockFile{bytes.NewReader(excelBuffer.Bytes())},
+	})
+	if err != nil {
+		t.Fatalf("UploadCatalog() error = %v", err)
+	}
+
+	expected := []int{500, 500, 200}
+	if len(batches) != len(expected) {
+		t.Fatalf("UploadCatalog() uploaded batches %v, want %v", batches, expected)
+	}
+	for i := range expected {
+		if batches[i] != expected[i] {
+			t.Errorf("UploadCatalog() uploaded batches %v, want %v", batches, expected)
+		}
+	}
+}
+
+func TestServerCatalog_UploadCatalog_Modes(t *testing.T) {
+	data := [][]string{
+		{"Model", "RAM", "HDD", "Location", "Price"},
+		{"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "$35.99"},
+		{"HP DL120G7", "4GBDDR3", "4x1TBSATA2", "AmsterdamAMS-01", "€39.99"},
+	}
+
+	tests := []struct {
+		name          string
+		mode          string
+		expected      *dto.UploadCatalogResp
+		expectedCount int
+		expectedError error
+	}{
+		{
+			name:          "default",
+			expected:      &dto.UploadCatalogResp{Upload: 1, Version: 1, Mode: dto.UploadModeAppend, Inserted: 2},
+			expectedCount: 7,
+		},
+		{
+			name:          "replace",
+			mode:          dto.UploadModeReplace,
+			expected:      &dto.UploadCatalogResp{Upload: 1, Version: 1, Mode: dto.UploadModeReplace, Inserted: 2, Deleted: 5},
+			expectedCount: 2,
+		},
+		{
+			name:          "upsert",
+			mode:          dto.UploadModeUpsert,
+			expected:      &dto.UploadCatalogResp{Upload: 1, Version: 1, Mode: dto.UploadModeUpsert, Updated: 1, Unchanged: 1},
+			expectedCount: 5,
+		},
+		{
+			name:          "unknown mode",
+			mode:          "merge",
+			expectedError: errors.New(`usecase:server_catalog:unknown upload mode "merge"`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			excelBuffer, err := createTestExcelFile(data)
+			if err != nil {
+				t.Fatalf("Failed to create test Excel file: %v", err)
+			}
+
+			mockRepo := &mockCatalogRepository{
+				uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+					return nil
+				},
+				upsertFunc: func(ctx context.Context, catalogs []models.ServerCatalog) (int, int, int, error) {
+					return 0, 1, 1, nil
+				},
+				// the new version starts from the 5 servers of the active one, unless it replaces them
+				activeVersion: &models.CatalogVersion{ID: 42, ServerCount: 5, Active: true},
+			}
+
+			uc := New(mockRepo, testPolicy, testLookups)
+			resp, err := uc.UploadCatalog(context.Background(), &dto.UploadCatalogCtr{
+				File: &mockFile{bytes.NewReader(excelBuffer.Bytes())},
+				Mode: tt.mode,
+			})
+			if (err != nil && tt.expectedError == nil) ||
+				(err == nil && tt.expectedError != nil) ||
+				(err != nil && tt.expectedError != nil && err.Error() != tt.expectedError.Error()) {
+				t.Fatalf("UploadCatalog() error = %v, want %v", err, tt.expectedError)
+			}
+			if !reflect.DeepEqual(resp, tt.expected) {
+				t.Errorf("UploadCatalog() = %+v, want %+v", resp, tt.expected)
+			}
+			if tt.expected == nil {
+				return
+			}
+			if mockRepo.activeVersion.ID != resp.Version || mockRepo.activeVersion.ServerCount != tt.expectedCount {
+				t.Errorf("UploadCatalog() activated %+v, want version %d with %d servers", mockRepo.activeVersion, resp.Version, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestServerCatalog_UploadCatalog_RowErrors(t *testing.T) {
+	excelBuffer, err := createTestExcelFile([][]string{
+		{"Model", "RAM", "HDD", "Location", "Price"},
+		{"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "$35.99"},
+		{"Dell R210-II", "16GB", "2x500GBSATA9", "AmsterdamAMS-01", "$35.99"},
+		{},
+		{"", "16GB DDR3", "2x500GBSATA2", "", "¥35.99"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	uploaded := false
+	mockRepo := &mockCatalogRepository{
+		uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+			uploaded = true
+			return nil
+		},
+	}
+
+	uc := New(mockRepo, testPolicy, testLookups)
+	_, err = uc.UploadCatalog(context.Background(), &dto.UploadCatalogCtr{
+		File: &mockFile{bytes.NewReader(excelBuffer.Bytes())},
+	})
+
+	var verr *utils.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("UploadCatalog() error = %v, want *utils.ValidationError", err)
+	}
+	if uploaded {
+		t.Error("UploadCatalog() uploaded rows although validation failed")
+	}
+
+	expected := []utils.RowError{
+		{Row: 3, Column: "RAM", Cell: "B3", Value: "16GB", Reason: "invalid RAM format"},
+		{Row: 3, Column: "HDD", Cell: "C3", Value: "2x500GBSATA9", Reason: "unknown HDD type: SATA9"},
+		{Row: 5, Column: "Model", Cell: "A5", Value: "", Reason: "model is required"},
+		{Row: 5, Column: "Location", Cell: "D5", Value: "", Reason: "location is required"},
+		{Row: 5, Column: "Price", Cell: "E5", Value: "¥35.99", Reason: "unknown currency symbol: ¥"},
+	}
+	if len(verr.Rows) != len(expected) {
+		t.Fatalf("UploadCatalog() reported %d errors, want %d: %v", len(verr.Rows), len(expected), verr.Rows)
+	}
+	for i := range expected {
+		if verr.Rows[i] != expected[i] {
+			t.Errorf("UploadCatalog() error[%d] = %+v, want %+v", i, verr.Rows[i], expected[i])
+		}
+	}
+}
+
+func TestServerCatalog_PreviewCatalog(t *testing.T) {
+	excelBuffer, err := createTestExcelFile([][]string{
+		{"Model", "RAM", "HDD", "Location", "Price"},
+		{"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "$35.99"},
+		{},
+		{"HP DL120G7", "4GBDDR4", "2x120GBSSD + 4x1TBSATA2", "SingaporeSIN-11", "S$39.99"},
+		{"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "$36.99"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	mockRepo := &mockCatalogRepository{
+		uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+			t.Error("PreviewCatalog() must not upload the catalog")
+			return nil
+		},
+	}
+
+	uc := New(mockRepo, testPolicy, testLookups)
+	preview, err := uc.PreviewCatalog(context.Background(), &dto.UploadCatalogCtr{
+		File: &mockFile{bytes.NewReader(excelBuffer.Bytes())},
+	})
+	if err != nil {
+		t.Fatalf("PreviewCatalog() error = %v", err)
+	}
+
+	expectedRows := []dto.CatalogPreviewRow{
+		{Row: 2, Model: "Dell R210-II", RamSize: 16, RamTypeID: utils.RAMTypeDDR3, Disks: []dto.CatalogPreviewDisk{{HDDCount: 2, HDDSize: 500, HDDTypeID: utils.HDDTypeSATA2}}, Storage: 1000, Location: "AmsterdamAMS-01", Price: 35.99, CurrencyID: utils.CurrencyUSD},
+		{Row: 4, Model: "HP DL120G7", RamSize: 4, RamTypeID: utils.RAMTypeDDR4, Disks: []dto.CatalogPreviewDisk{{HDDCount: 2, HDDSize: 120, HDDTypeID: utils.HDDTypeSSD}, {HDDCount: 4, HDDSize: 1024, HDDTypeID: utils.HDDTypeSATA2}}, Storage: 4336, Location: "SingaporeSIN-11", Price: 39.99, CurrencyID: utils.CurrencySGD},
+		{Row: 5, Model: "Dell R210-II", RamSize: 16, RamTypeID: utils.RAMTypeDDR3, Disks: []dto.CatalogPreviewDisk{{HDDCount: 2, HDDSize: 500, HDDTypeID: utils.HDDTypeSATA2}}, Storage: 1000, Location: "AmsterdamAMS-01", Price: 36.99, CurrencyID: utils.CurrencyUSD},
+	}
+	if len(preview.Rows) != len(expectedRows) {
+		t.Fatalf("PreviewCatalog() returned %d rows, want %d", len(preview.Rows), len(expectedRows))
+	}
+	for i := range expectedRows {
+		if !reflect.DeepEqual(preview.Rows[i], expectedRows[i]) {
+			t.Errorf("PreviewCatalog() row[%d] = %+v, want %+v", i, preview.Rows[i], expectedRows[i])
+		}
+	}
+
+	expectedWarnings := []string{
+		"row 3 is empty and was skipped",
+		"row 5 duplicates the server of row 2",
+	}
+	if !compareStringSlices(preview.Warnings, expectedWarnings) {
+		t.Errorf("PreviewCatalog() warnings = %v, want %v", preview.Warnings, expectedWarnings)
+	}
+}
+
 func TestServerCatalog_GetLocations(t *testing.T) {
 	tests := []struct {
 		name          string
-		mockLocations func(ctx context.Context) ([]string, error)
-		expected      []string
+		mockLocations func(ctx context.Context) ([]models.Location, error)
+		expected      []dto.LocationResp
 		expectedError error
 	}{
 		{
 			name: "successful locations retrieval",
-			mockLocations: func(ctx context.Context) ([]string, error) {
-				return []string{"Amsterdam", "Singapore", "London"}, nil
+			mockLocations: func(ctx context.Context) ([]models.Location, error) {
+				return []models.Location{
+					{ID: 1, Name: "AmsterdamAMS-01", City: "Amsterdam", Code: "AMS-01", Country: "Netherlands"},
+					{ID: 2, Name: "SingaporeSIN-11", City: "Singapore", Code: "SIN-11", Country: "Singapore"},
+				}, nil
+			},
+			expected: []dto.LocationResp{
+				{Name: "AmsterdamAMS-01", City: "Amsterdam", Code: "AMS-01", Country: "Netherlands"},
+				{Name: "SingaporeSIN-11", City: "Singapore", Code: "SIN-11", Country: "Singapore"},
 			},
-			expected:      []string{"Amsterdam", "Singapore", "London"},
 			expectedError: nil,
 		},
 		{
 			name: "repository error",
-			mockLocations: func(ctx context.Context) ([]string, error) {
+			mockLocations: func(ctx context.Context) ([]models.Location, error) {
 				return nil, errors.New("database error")
 			},
 			expected:      nil,
@@ -180,7 +600,7 @@ func TestServerCatalog_GetLocations(t *testing.T) {
 				getLocationsFunc: tt.mockLocations,
 			}
 
-			uc := New(mockRepo)
+			uc := New(mockRepo, testPolicy, testLookups)
 			locations, err := uc.GetLocations(context.Background())
 
 			if (err != nil && tt.expectedError == nil) ||
@@ -189,7 +609,7 @@ func TestServerCatalog_GetLocations(t *testing.T) {
 				t.Errorf("GetLocations() error = %v, want %v", err, tt.expectedError)
 			}
 
-			if err == nil && !compareStringSlices(locations, tt.expected) {
+			if err == nil && !reflect.DeepEqual(locations, tt.expected) {
 				t.Errorf("GetLocations() = %v, want %v", locations, tt.expected)
 			}
 		})
@@ -227,7 +647,7 @@ func TestServerCatalog_GetHDDTypes(t *testing.T) {
 				getHDDTypesFunc: tt.mockHDDTypes,
 			}
 
-			uc := New(mockRepo)
+			uc := New(mockRepo, testPolicy, testLookups)
 			hddTypes, err := uc.GetHDDTypes(context.Background())
 
 			if (err != nil && tt.expectedError == nil) ||
@@ -267,9 +687,7 @@ func TestServerCatalog_GetListOfServers(t *testing.T) {
 						Model:    "Dell R210-II",
 						RamSize:  16,
 						RamType:  utils.RAMTypeDDR3,
-						HDDSize:  500,
-						HDDCount: 2,
-						HDDType:  utils.HDDTypeSATA2,
+						Disks: []models.ServerDisk{{HDDCount: 2, HDDSize: 500, HDDType: utils.HDDTypeSATA2}},
 						Location: "Amsterdam",
 						Price:    35.99,
 						Currency: utils.CurrencyUSD,
@@ -306,7 +724,7 @@ func TestServerCatalog_GetListOfServers(t *testing.T) {
 				getServersFunc: tt.mockServers,
 			}
 
-			uc := New(mockRepo)
+			uc := New(mockRepo, testPolicy, testLookups)
 			servers, err := uc.GetListOfServers(context.Background(), tt.ctr)
 
 			if (err != nil && tt.expectedError == nil) ||
@@ -333,3 +751,133 @@ func compareStringSlices(a, b []string) bool {
 	}
 	return true
 }
+
+func TestServerCatalog_ActivateVersion(t *testing.T) {
+	mockRepo := &mockCatalogRepository{
+		versions: []models.CatalogVersion{
+			{ID: 1, Mode: dto.UploadModeReplace, ServerCount: 486},
+			{ID: 2, Mode: dto.UploadModeAppend, ServerCount: 490, Active: true},
+		},
+	}
+	uc := New(mockRepo, testPolicy, testLookups)
+
+	resp, err := uc.ActivateVersion(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ActivateVersion() error = %v", err)
+	}
+	if resp.ID != 1 || !resp.Active || resp.ServerCount != 486 {
+		t.Errorf("ActivateVersion() = %+v, want active version 1", resp)
+	}
+
+	if _, err := uc.ActivateVersion(context.Background(), 3); !errors.Is(err, utils.ErrVersionNotFound) {
+		t.Errorf("ActivateVersion() error = %v, want %v", err, utils.ErrVersionNotFound)
+	}
+}
+
+func TestParseHDD(t *testing.T) {
+	tests := []struct {
+		name          string
+		hdd           string
+		expected      []diskGroup
+		expectedError error
+	}{
+		{
+			name:     "single disk group",
+			hdd:      "4x1TBSATA2",
+			expected: []diskGroup{{Count: 4, Size: 1024, Type: "SATA2"}},
+		},
+		{
+			name:     "mixed disk groups",
+			hdd:      "2x120GBSSD+4x2TBsata2",
+			expected: []diskGroup{{Count: 2, Size: 120, Type: "SSD"}, {Count: 4, Size: 2048, Type: "SATA2"}},
+		},
+		{
+			name:     "spaces around the separator",
+			hdd:      " 2x120GBSSD + 2x960GBSSD ",
+			expected: []diskGroup{{Count: 2, Size: 120, Type: "SSD"}, {Count: 2, Size: 960, Type: "SSD"}},
+		},
+		{
+			name:          "empty disk group",
+			hdd:           "2x120GBSSD+",
+			expectedError: errors.New(`invalid HDD format: "2x120GBSSD+"`),
+		},
+		{
+			name:          "invalid disk group",
+			hdd:           "2x120GBSSD+4xSATA2",
+			expectedError: errors.New(`invalid HDD format: "2x120GBSSD+4xSATA2"`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, err := parseHDD(tt.hdd)
+			if (err != nil && tt.expectedError == nil) ||
+				(err == nil && tt.expectedError != nil) ||
+				(err != nil && tt.expectedError != nil && err.Error() != tt.expectedError.Error()) {
+				t.Fatalf("parseHDD() error = %v, want %v", err, tt.expectedError)
+			}
+			if !reflect.DeepEqual(groups, tt.expected) {
+				t.Errorf("parseHDD() = %+v, want %+v", groups, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseRAM(t *testing.T) {
+	tests := []struct {
+		name          string
+		ram           string
+		expected      ramSpec
+		expectedError error
+	}{
+		{
+			name:     "size and type",
+			ram:      "16GBDDR3",
+			expected: ramSpec{Size: 16, Type: "DDR3"},
+		},
+		{
+			name:     "ECC memory",
+			ram:      "64GB DDR4 ECC",
+			expected: ramSpec{Size: 64, Type: "DDR4", ECC: true},
+		},
+		{
+			name:     "module speed",
+			ram:      "128GBDDR5-4800",
+			expected: ramSpec{Size: 128, Type: "DDR5", Speed: 4800},
+		},
+		{
+			name:     "terabyte memory",
+			ram:      "1TB DDR4",
+			expected: ramSpec{Size: 1024, Type: "DDR4"},
+		},
+		{
+			name:     "registered memory with speed unit",
+			ram:      "256gb ddr4 2933MT/s REG ECC",
+			expected: ramSpec{Size: 256, Type: "DDR4", Speed: 2933, ECC: true},
+		},
+		{
+			name:          "missing type",
+			ram:           "16GB",
+			expectedError: errors.New("invalid RAM format"),
+		},
+		{
+			name:          "unknown suffix",
+			ram:           "16GBDDR4 LRDIMMX",
+			expectedError: errors.New("invalid RAM format"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := parseRAM(tt.ram)
+			if (err != nil && tt.expectedError == nil) ||
+				(err == nil && tt.expectedError != nil) ||
+				(err != nil && tt.expectedError != nil && err.Error() != tt.expectedError.Error()) {
+				t.Fatalf("parseRAM() error = %v, want %v", err, tt.expectedError)
+			}
+			if spec != tt.expected {
+				t.Errorf("parseRAM() = %+v, want %+v", spec, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/server-catalog/internal/config"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"github.com/server-catalog/repository"
+	"github.com/server-catalog/transformer"
+	"log"
+	"sync"
+)
+
+// defaults used when the upload policy doesn't configure the worker pool
+const (
+	defaultJobWorkers   = 2
+	defaultJobQueueSize = 16
+)
+
+// UploadJobs runs catalog uploads in a bounded pool of in-process workers.
+// Job metadata is persisted so the status of a job outlives the process.
+type UploadJobs struct {
+	JobRepo repository.JobRepository
+	Catalog CatalogUseCase
+	Policy  *config.UploadPolicy
+
+	queue  chan uploadJob
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+	closed bool
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// uploadJob is a queued upload together with the file it owns
+type uploadJob struct {
+	job *models.UploadJob
+	ctr *dto.UploadCatalogCtr
+}
+
+func NewJobs(jr repository.JobRepository, cuc CatalogUseCase, policy *config.UploadPolicy) JobUseCase {
+	queueSize := defaultJobQueueSize
+	if policy != nil && policy.QueueSize > 0 {
+		queueSize = policy.QueueSize
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &UploadJobs{
+		JobRepo: jr,
+		Catalog: cuc,
+		Policy:  policy,
+		queue:   make(chan uploadJob, queueSize),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start fails the jobs left unfinished by a previous run and starts the workers
+func (uj *UploadJobs) Start(ctx context.Context) error {
+	n, err := uj.JobRepo.FailUnfinishedJobs(ctx, "interrupted by a restart of the server")
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		log.Printf("usecase:upload_job:: marked %d interrupted upload jobs as failed\n", n)
+	}
+
+	workers := defaultJobWorkers
+	if uj.Policy != nil && uj.Policy.Workers > 0 {
+		workers = uj.Policy.Workers
+	}
+	for i := 0; i < workers; i++ {
+		uj.wg.Add(1)
+		go uj.work()
+	}
+	return nil
+}
+
+// SubmitUpload queues an upload and returns its job. The job takes ownership of
+// ctr.File and closes it once done, unless an error is returned.
+func (uj *UploadJobs) SubmitUpload(ctx context.Context, ctr *dto.UploadCatalogCtr) (*dto.UploadJobResp, error) {
+	uj.mu.RLock()
+	defer uj.mu.RUnlock()
+	if uj.closed {
+		return nil, fmt.Errorf("usecase:upload_job:: server is shutting down: %w", utils.ErrJobQueueFull)
+	}
+
+	job := &models.UploadJob{State: models.JobStateQueued}
+	if err := uj.JobRepo.CreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+	resp := transformer.TransformUploadJob(job)
+
+	select {
+	case uj.queue <- uploadJob{job: job, ctr: ctr}:
+		return resp, nil
+	default:
+		job.State = models.JobStateFailed
+		job.Error = utils.ErrJobQueueFull.Error()
+		if err := uj.JobRepo.UpdateJob(ctx, job); err != nil {
+			log.Println(err)
+		}
+		return nil, utils.ErrJobQueueFull
+	}
+}
+
+func (uj *UploadJobs) GetJob(ctx context.Context, id uint) (*dto.UploadJobResp, error) {
+	job, err := uj.JobRepo.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return transformer.TransformUploadJob(job), nil
+}
+
+// Shutdown stops accepting jobs and waits for the queued and running ones to finish.
+// When ctx expires first the remaining jobs are canceled and marked as failed.
+func (uj *UploadJobs) Shutdown(ctx context.Context) error {
+	uj.mu.Lock()
+	if !uj.closed {
+		uj.closed = true
+		close(uj.queue)
+	}
+	uj.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		uj.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		uj.cancel()
+		return nil
+	case <-ctx.Done():
+		uj.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (uj *UploadJobs) work() {
+	defer uj.wg.Done()
+	for j := range uj.queue {
+		uj.run(j)
+	}
+}
+
+// run processes a single upload and records every state change of the job
+func (uj *UploadJobs) run(j uploadJob) {
+	defer j.ctr.File.Close()
+
+	job := j.job
+	save := func() {
+		// the final state must be stored even when the job was canceled
+		if err := uj.JobRepo.UpdateJob(context.WithoutCancel(uj.ctx), job); err != nil {
+			log.Println(err)
+		}
+	}
+
+	j.ctr.Progress = func(stage string, rows int) {
+		job.State = stage
+		job.RowsProcessed = rows
+		save()
+	}
+
+	resp, err := uj.Catalog.UploadCatalog(uj.ctx, j.ctr)
+	if err == nil {
+		job.State = models.JobStateDone
+		if result, err := json.Marshal(resp); err == nil {
+			job.Result = string(result)
+		}
+		save()
+		return
+	}
+
+	job.State = models.JobStateFailed
+	job.Error = err.Error()
+	var verr *utils.ValidationError
+	if errors.As(err, &verr) {
+		if report, err := json.Marshal(verr.Errors()); err == nil {
+			job.Report = string(report)
+		}
+	}
+	save()
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/server-catalog/internal/config"
+	"github.com/server-catalog/internal/dto"
+	"github.com/server-catalog/internal/utils"
+	"github.com/server-catalog/models"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// mockJobRepository keeps jobs in memory and records every state they went through
+type mockJobRepository struct {
+	mu     sync.Mutex
+	jobs   map[uint]models.UploadJob
+	states map[uint][]string
+	failed int64
+}
+
+func newMockJobRepository() *mockJobRepository {
+	return &mockJobRepository{
+		jobs:   make(map[uint]models.UploadJob),
+		states: make(map[uint][]string),
+	}
+}
+
+func (m *mockJobRepository) CreateJob(ctx context.Context, job *models.UploadJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job.ID = uint(len(m.jobs) + 1)
+	m.jobs[job.ID] = *job
+	m.states[job.ID] = []string{job.State}
+	return nil
+}
+
+func (m *mockJobRepository) UpdateJob(ctx context.Context, job *models.UploadJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[job.ID] = *job
+	states := m.states[job.ID]
+	if states[len(states)-1] != job.State {
+		m.states[job.ID] = append(states, job.State)
+	}
+	return nil
+}
+
+func (m *mockJobRepository) GetJob(ctx context.Context, id uint) (*models.UploadJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, utils.ErrJobNotFound
+	}
+	return &job, nil
+}
+
+func (m *mockJobRepository) FailUnfinishedJobs(ctx context.Context, reason string) (int64, error) {
+	return m.failed, nil
+}
+
+func newTestUpload(t *testing.T, data [][]string) *dto.UploadCatalogCtr {
+	excelBuffer, err := createTestExcelFile(data)
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+	return &dto.UploadCatalogCtr{File: &mockFile{bytes.NewReader(excelBuffer.Bytes())}}
+}
+
+func TestUploadJobs_SubmitUpload(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           [][]string
+		expectedStates []string
+		expectedRows   int
+		expectedReport utils.Errors
+		expectedResult *dto.UploadCatalogResp
+	}{
+		{
+			name: "successful upload",
+			data: [][]string{
+				{"Model", "RAM", "HDD", "Location", "Price"},
+				{"Dell R210-II", "16GB DDR3", "2x500GBSATA2", "AmsterdamAMS-01", "$35.99"},
+				{"HP DL120G7", "4GBDDR3", "4x1TBSATA2", "AmsterdamAMS-01", "€39.99"},
+			},
+			expectedStates: []string{models.JobStateQueued, models.JobStateParsing, models.JobStateInserting, models.JobStateDone},
+			expectedRows:   2,
+			expectedResult: &dto.UploadCatalogResp{Upload: 1, Version: 1, Mode: dto.UploadModeAppend, Inserted: 2},
+		},
+		{
+			name: "invalid rows",
+			data: [][]string{
+				{"Model", "RAM", "HDD", "Location", "Price"},
+				{"Dell R210-II", "16GB", "2x500GBSATA2", "AmsterdamAMS-01", "$35.99"},
+			},
+			expectedStates: []string{models.JobStateQueued, models.JobStateParsing, models.JobStateFailed},
+			expectedRows:   1,
+			expectedReport: utils.Errors{
+				"B2": {`row 2, column RAM, value "16GB": invalid RAM format`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobRepo := newMockJobRepository()
+			catalog := New(&mockCatalogRepository{
+				uploadFunc: func(ctx context.Context, catalogs []models.ServerCatalog) error {
+					return nil
+				},
+			}, testPolicy, testLookups)
+
+			jobs := NewJobs(jobRepo, catalog, &config.UploadPolicy{Workers: 1, QueueSize: 1})
+			if err := jobs.Start(context.Background()); err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+
+			resp, err := jobs.SubmitUpload(context.Background(), newTestUpload(t, tt.data))
+			if err != nil {
+				t.Fatalf("SubmitUpload() error = %v", err)
+			}
+			if resp.State != models.JobStateQueued {
+				t.Errorf("SubmitUpload() state = %v, want %v", resp.State, models.JobStateQueued)
+			}
+
+			// shutdown drains the queue
+			if err := jobs.Shutdown(context.Background()); err != nil {
+				t.Fatalf("Shutdown() error = %v", err)
+			}
+
+			job, err := jobs.GetJob(context.Background(), resp.ID)
+			if err != nil {
+				t.Fatalf("GetJob() error = %v", err)
+			}
+			if !reflect.DeepEqual(jobRepo.states[resp.ID], tt.expectedStates) {
+				t.Errorf("job went through %v, want %v", jobRepo.states[resp.ID], tt.expectedStates)
+			}
+			if job.RowsProcessed != tt.expectedRows {
+				t.Errorf("GetJob() rows processed = %v, want %v", job.RowsProcessed, tt.expectedRows)
+			}
+			if !reflect.DeepEqual(job.Report, tt.expectedReport) {
+				t.Errorf("GetJob() report = %v, want %v", job.Report, tt.expectedReport)
+			}
+			if !reflect.DeepEqual(job.Result, tt.expectedResult) {
+				t.Errorf("GetJob() result = %+v, want %+v", job.Result, tt.expectedResult)
+			}
+		})
+	}
+}
+
+func TestUploadJobs_SubmitUpload_QueueFull(t *testing.T) {
+	jobRepo := newMockJobRepository()
+	jobs := NewJobs(jobRepo, New(&mockCatalogRepository{}, testPolicy, testLookups), &config.UploadPolicy{QueueSize: 1})
+
+	// workers are not started, so the first job stays queued
+	data := [][]string{{"Model", "RAM", "HDD", "Location", "Price"}}
+	if _, err := jobs.SubmitUpload(context.Background(), newTestUpload(t, data)); err != nil {
+		t.Fatalf("SubmitUpload() error = %v", err)
+	}
+
+	_, err := jobs.SubmitUpload(context.Background(), newTestUpload(t, data))
+	if !errors.Is(err, utils.ErrJobQueueFull) {
+		t.Fatalf("SubmitUpload() error = %v, want %v", err, utils.ErrJobQueueFull)
+	}
+
+	job, _ := jobs.GetJob(context.Background(), 2)
+	if job.State != models.JobStateFailed {
+		t.Errorf("rejected job state = %v, want %v", job.State, models.JobStateFailed)
+	}
+}
+
+func TestUploadJobs_SubmitUpload_AfterShutdown(t *testing.T) {
+	jobs := NewJobs(newMockJobRepository(), New(&mockCatalogRepository{}, testPolicy, testLookups), nil)
+	if err := jobs.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := jobs.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	data := [][]string{{"Model", "RAM", "HDD", "Location", "Price"}}
+	_, err := jobs.SubmitUpload(context.Background(), newTestUpload(t, data))
+	if !errors.Is(err, utils.ErrJobQueueFull) {
+		t.Errorf("SubmitUpload() error = %v, want %v", err, utils.ErrJobQueueFull)
+	}
+}